@@ -8,6 +8,7 @@ import (
 	"github.com/gelleson/changescout/changescout/internal/api/gql"
 	httpplatform "github.com/gelleson/changescout/changescout/internal/api/http"
 	"github.com/gelleson/changescout/changescout/internal/api/http/middlewares"
+	"github.com/gelleson/changescout/changescout/internal/api/rest"
 	"github.com/gelleson/changescout/changescout/internal/app/services"
 	"github.com/gelleson/changescout/changescout/internal/app/services/diff"
 	"github.com/gelleson/changescout/changescout/internal/app/services/requesters"
@@ -20,6 +21,8 @@ import (
 	"github.com/gelleson/changescout/changescout/internal/domain"
 	entrepo "github.com/gelleson/changescout/changescout/internal/infrastructure/database/ent"
 	"github.com/gelleson/changescout/changescout/internal/pkg/clis"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/gelleson/changescout/changescout/internal/pkg/ratelimit"
 	"github.com/gelleson/changescout/changescout/internal/platform/logger"
 	"github.com/gelleson/changescout/changescout/internal/utils/transform"
 	"github.com/gelleson/changescout/changescout/pkg/flags"
@@ -27,6 +30,7 @@ import (
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
 	"log"
+	"strings"
 )
 
 var StartServer = &cli.Command{
@@ -44,6 +48,14 @@ var StartServer = &cli.Command{
 		clis.FlagsSchedulerInterval,
 		clis.FlagsBrowserManagedInstanceURL,
 		clis.FlagsBrowserDisable,
+		clis.FlagsExecEnabled,
+		clis.FlagsExecAllowedCommands,
+		clis.FlagsExecAllowedEnv,
+		clis.FlagsExecMaxTimeout,
+		clis.FlagsPreviewRateLimit,
+		clis.FlagsPluginsDir,
+		clis.FlagsPluginsMemoryLimit,
+		clis.FlagsPluginsTimeout,
 	),
 	Action: func(c *cli.Context) error {
 		logger.SetLevel(clis.FlagsLogLevel.Get(c))
@@ -58,6 +70,17 @@ var StartServer = &cli.Command{
 		if err != nil {
 			log.Fatal("failed to open database connection", zap.Error(err))
 		}
+		var plugins *processors.PluginRegistry
+		if dir := clis.FlagsPluginsDir.Get(c); dir != "" {
+			plugins, err = processors.NewPluginRegistry(c.Context, dir, processors.PluginOptions{
+				MemoryLimit: uint32(clis.FlagsPluginsMemoryLimit.Get(c)) << 20,
+				Timeout:     clis.FlagsPluginsTimeout.Get(c),
+			})
+			if err != nil {
+				log.Fatal("failed to load plugins", zap.Error(err))
+			}
+			logger.L("plugins").Info("Loaded plugins", zap.Strings("names", plugins.Names()))
+		}
 		server.WithMiddlewares(
 			logger.WithLogger(logger.L("http")),
 			middlewares.JWTAuth(middlewares.JWTAuthConfig{
@@ -72,6 +95,25 @@ var StartServer = &cli.Command{
 			watermillzap.NewLogger(logger.L("pubsub")),
 		)
 
+		requester := requesters.New(requesters.Options{
+			Browser: requesters.BrowserOption{
+				Enable: !clis.FlagsBrowserDisable.Get(c),
+				ManagedInstanceURL: transform.ToPtr(
+					clis.FlagsBrowserManagedInstanceURL.Get(c),
+				),
+			},
+			Exec: requesters.ExecOption{
+				Enable: clis.FlagsExecEnabled.Get(c),
+				AllowedCommands: splitList(
+					clis.FlagsExecAllowedCommands.Get(c),
+				),
+				AllowedEnv: splitList(
+					clis.FlagsExecAllowedEnv.Get(c),
+				),
+				MaxTimeout: clis.FlagsExecMaxTimeout.Get(c),
+			},
+		})
+
 		b := broker.New(
 			logger.L("broker"),
 			&broker.UseCases{
@@ -79,18 +121,12 @@ var StartServer = &cli.Command{
 					services.NewWebsiteService(
 						entrepo.NewWebsiteRepository(client),
 					),
-					requesters.New(requesters.Options{
-						Browser: requesters.BrowserOption{
-							Enable: !clis.FlagsBrowserDisable.Get(c),
-							ManagedInstanceURL: transform.ToPtr(
-								clis.FlagsBrowserManagedInstanceURL.Get(c),
-							),
-						},
-					}),
+					requester,
 					services.NewCheckService(
 						entrepo.NewCheckRepository(client),
 					),
 					diff.NewDiffService(),
+					plugins,
 				),
 				WebsiteUseCase: usecases.NewWebsiteUseCase(
 					services.NewWebsiteService(
@@ -137,10 +173,16 @@ var StartServer = &cli.Command{
 			go s.Run(context.Background())
 		}
 
+		previewLimiter := ratelimit.New(clis.FlagsPreviewRateLimit.Get(c))
+
 		ghandler := gql.BuildHandler(&gql.HandlerConfig{
 			Secret:           clis.FlagsSecret.Get(c),
 			SecretExpiration: clis.FlagsSecretExpiration.Get(c),
 			Client:           client,
+			Requester:        requester,
+			ExecEnabled:      clis.FlagsExecEnabled.Get(c),
+			Plugins:          plugins,
+			PreviewLimiter:   previewLimiter,
 		})
 		server.Register("POST", "/query", func(c echo.Context) error {
 			ghandler.Schema().ServeHTTP(c.Response(), c.Request())
@@ -152,8 +194,42 @@ var StartServer = &cli.Command{
 			return nil
 		})
 
+		previewHandler := rest.NewPreviewHandler(
+			usecases.NewWebsiteUseCase(
+				services.NewWebsiteService(
+					entrepo.NewWebsiteRepository(client),
+				),
+				services.NewUserService(
+					entrepo.NewUserRepository(client),
+				),
+			),
+			check.NewUseCase(
+				services.NewWebsiteService(
+					entrepo.NewWebsiteRepository(client),
+				),
+				requester,
+				services.NewCheckService(
+					entrepo.NewCheckRepository(client),
+				),
+				diff.NewDiffService(),
+				plugins,
+			),
+			previewLimiter,
+		)
+		server.Register("GET", "/api/websites/:id/preview", previewHandler.Preview, middlewares.RequireAuth())
+
 		log.Fatal(server.Start())
 
 		return nil
 	},
 }
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -30,6 +30,10 @@ func (b *Broker) HandleWebsiteCheck() HandlerFunc {
 			return err
 		}
 
+		if res.ProcessingError != nil {
+			return b.usecases.NotificationUseCase.NotifyProcessingError(msg.Context(), site.ID, res.ProcessingError)
+		}
+
 		if !res.HasChanges {
 			return nil
 		}
@@ -13,6 +13,8 @@ import (
 	"github.com/gelleson/changescout/changescout/internal/app/usecases/check"
 	entrepo "github.com/gelleson/changescout/changescout/internal/infrastructure/database/ent"
 	"github.com/gelleson/changescout/changescout/internal/infrastructure/database/ent/ent"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/gelleson/changescout/changescout/internal/pkg/ratelimit"
 	"net/http"
 	"time"
 )
@@ -21,6 +23,14 @@ type HandlerConfig struct {
 	Secret           string
 	SecretExpiration time.Duration
 	Client           *ent.Client
+	// Requester fetches the websites for the previews, the plain HTTP requester is used when it's not set.
+	Requester check.HttpService
+	// Plugins are the processor plugins loaded on the server, the plugin steps are rejected when it's not set.
+	Plugins *processors.PluginRegistry
+	// ExecEnabled accepts the exec mode websites, it follows the exec flag of the server.
+	ExecEnabled bool
+	// PreviewLimiter limits the previews per user, the previews are not limited when it's not set.
+	PreviewLimiter *ratelimit.Limiter
 }
 
 type Handler struct {
@@ -37,6 +47,11 @@ func (h *Handler) PlaygroundHandler() http.Handler {
 }
 
 func BuildHandler(conf *HandlerConfig) *Handler {
+	var requester check.HttpService = http2.New(http.DefaultClient)
+	if conf.Requester != nil {
+		requester = conf.Requester
+	}
+
 	return &Handler{
 		schema: handler.NewDefaultServer(
 			generated.NewExecutableSchema(
@@ -45,6 +60,8 @@ func BuildHandler(conf *HandlerConfig) *Handler {
 						WebsiteUseCase: usecases.NewWebsiteUseCase(
 							services.NewWebsiteService(
 								entrepo.NewWebsiteRepository(conf.Client),
+								services.WithExecEnabled(conf.ExecEnabled),
+								services.WithPlugins(conf.Plugins),
 							),
 							services.NewUserService(
 								entrepo.NewUserRepository(conf.Client),
@@ -63,13 +80,17 @@ func BuildHandler(conf *HandlerConfig) *Handler {
 						CheckUseCase: check.NewUseCase(
 							services.NewWebsiteService(
 								entrepo.NewWebsiteRepository(conf.Client),
+								services.WithExecEnabled(conf.ExecEnabled),
+								services.WithPlugins(conf.Plugins),
 							),
-							http2.New(http.DefaultClient),
+							requester,
 							services.NewCheckService(
 								entrepo.NewCheckRepository(conf.Client),
 							),
 							diff.NewDiffService(),
+							conf.Plugins,
 						),
+						PreviewLimiter: conf.PreviewLimiter,
 					},
 					Directives: generated.DirectiveRoot{
 						IsAuthenticated: directive.IsAuth(),
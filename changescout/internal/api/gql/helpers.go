@@ -4,10 +4,23 @@ import (
 	"github.com/gelleson/changescout/changescout/internal/api/gql/model"
 	"github.com/gelleson/changescout/changescout/internal/domain"
 	"github.com/gelleson/changescout/changescout/internal/utils/transform"
-	"github.com/gelleson/changescout/changescout/pkg/diff"
+	"github.com/google/uuid"
 	"net/http"
+	"time"
 )
 
+func buildWebsite(input model.WebsiteCreateInput, userID uuid.UUID) domain.Website {
+	return domain.Website{
+		URL:     input.URL,
+		Name:    input.Name,
+		Enabled: input.Enabled,
+		Mode:    input.Mode,
+		Cron:    input.Cron,
+		Setting: buildSetting(input.Setting),
+		UserID:  userID,
+	}
+}
+
 func buildSetting(input *model.SettingInput) domain.Setting {
 	var setting model.SettingInput
 	if input != nil {
@@ -15,15 +28,405 @@ func buildSetting(input *model.SettingInput) domain.Setting {
 	}
 
 	return domain.Setting{
-		Headers:       http.Header{},
-		UserAgent:     transform.ToValueOrDefault(setting.UserAgent, ""),
-		Referer:       transform.ToValueOrDefault(setting.Referer, ""),
-		Template:      diff.GetUpdatedValueWithPointer(input.Template, input.Template),
-		Method:        setting.Method.String(),
-		Selectors:     setting.Selectors,
-		Deduplication: transform.ToValueOrDefault(setting.Deduplication, false),
-		Trim:          transform.ToValueOrDefault(setting.Trim, false),
-		Sort:          transform.ToValueOrDefault(setting.Sort, false),
-		JSONPath:      setting.JSONPath,
+		Headers:             http.Header{},
+		UserAgent:           transform.ToValueOrDefault(setting.UserAgent, ""),
+		Referer:             transform.ToValueOrDefault(setting.Referer, ""),
+		Template:            setting.Template,
+		Method:              setting.Method.String(),
+		Body:                transform.ToValueOrDefault(setting.Body, ""),
+		Ignore:              buildIgnoreOption(setting.Ignore),
+		Selectors:           setting.Selectors,
+		Records:             buildRecordOption(setting.Records),
+		Table:               buildTableOption(setting.Table),
+		Convert:             buildConvertOption(setting.Convert),
+		XPath:               setting.Xpath,
+		Deduplication:       transform.ToValueOrDefault(setting.Deduplication, false),
+		DeduplicationOption: buildDeduplicationOption(setting.DeduplicationOption),
+		Trim:                transform.ToValueOrDefault(setting.Trim, false),
+		Sort:                transform.ToValueOrDefault(setting.Sort, false),
+		SortOption:          buildSortOption(setting.SortOption),
+		JSONPath:            setting.JSONPath,
+		JQ:                  transform.ToValueOrDefault(setting.Jq, ""),
+		Script:              transform.ToValueOrDefault(setting.Script, ""),
+		Plugin:              transform.ToValueOrDefault(setting.Plugin, ""),
+		XML:                 buildXMLOption(setting.XML),
+		CSV:                 buildCSVOption(setting.CSV),
+		Regex:               buildRegexOption(setting.Regex),
+		Filter:              buildFilterOption(setting.Filter),
+		TLS:                 buildTLSOption(setting.TLS),
+		Exec:                buildExecOption(setting.Exec),
+		Crawl:               buildCrawlOption(setting.Crawl),
+		Pagination:          buildPaginationOption(setting.Pagination),
+		Workflow:            buildWorkflow(setting.Workflow),
+		Pipeline:            buildPipeline(setting.Pipeline),
+		Diff:                buildDiffOption(setting.Diff),
+		Threshold:           buildThresholdOption(setting.Threshold),
+		Numeric:             buildNumericOption(setting.Numeric),
+		Triggers:            buildTriggers(setting.Triggers),
+	}
+}
+
+func buildPipeline(input []*model.ProcessingStepInput) []domain.ProcessingStep {
+	if len(input) == 0 {
+		return nil
+	}
+
+	steps := make([]domain.ProcessingStep, 0, len(input))
+	for _, step := range input {
+		steps = append(steps, domain.ProcessingStep{
+			Type:          step.Type,
+			Ignore:        buildIgnoreOption(step.Ignore),
+			Selectors:     step.Selectors,
+			Records:       buildRecordOption(step.Records),
+			Table:         buildTableOption(step.Table),
+			Convert:       buildConvertOption(step.Convert),
+			XPath:         step.Xpath,
+			XML:           buildXMLOption(step.XML),
+			JSONPath:      step.JSONPath,
+			JQ:            transform.ToValueOrDefault(step.Jq, ""),
+			Script:        transform.ToValueOrDefault(step.Script, ""),
+			Plugin:        transform.ToValueOrDefault(step.Plugin, ""),
+			CSV:           buildCSVOption(step.CSV),
+			Regex:         buildRegexOption(step.Regex),
+			Filter:        buildFilterOption(step.Filter),
+			Deduplication: buildDeduplicationOption(step.Deduplication),
+			Sort:          buildSortOption(step.Sort),
+		})
+	}
+	return steps
+}
+
+func buildDiffOption(input *model.DiffOptionInput) domain.DiffOption {
+	if input == nil {
+		return domain.DiffOption{}
+	}
+
+	return domain.DiffOption{
+		JSONArrayKey: transform.ToValueOrDefault(input.JSONArrayKey, ""),
+	}
+}
+
+func buildThresholdOption(input *model.ThresholdOptionInput) domain.ThresholdOption {
+	if input == nil {
+		return domain.ThresholdOption{}
+	}
+
+	return domain.ThresholdOption{
+		MinChangePercent: transform.ToValueOrDefault(input.MinChangePercent, 0),
+		MinChangedChars:  transform.ToValueOrDefault(input.MinChangedChars, 0),
+		MinChangedLines:  transform.ToValueOrDefault(input.MinChangedLines, 0),
+		ChangeTypes:      input.ChangeTypes,
+	}
+}
+
+func buildNumericOption(input *model.NumericOptionInput) domain.NumericOption {
+	if input == nil {
+		return domain.NumericOption{}
+	}
+
+	return domain.NumericOption{
+		Enabled:      transform.ToValueOrDefault(input.Enabled, false),
+		Selector:     transform.ToValueOrDefault(input.Selector, ""),
+		JSONPath:     transform.ToValueOrDefault(input.JSONPath, ""),
+		Regex:        transform.ToValueOrDefault(input.Regex, ""),
+		Locale:       transform.ToValueOrDefault(input.Locale, ""),
+		Above:        input.Above,
+		Below:        input.Below,
+		DeltaPercent: input.DeltaPercent,
+	}
+}
+
+func buildTriggers(input []*model.TriggerInput) []domain.Trigger {
+	if len(input) == 0 {
+		return nil
+	}
+
+	triggers := make([]domain.Trigger, 0, len(input))
+	for _, trigger := range input {
+		triggers = append(triggers, domain.Trigger{
+			Type:       trigger.Type,
+			Value:      trigger.Value,
+			IgnoreCase: transform.ToValueOrDefault(trigger.IgnoreCase, false),
+		})
+	}
+	return triggers
+}
+
+func buildWorkflow(input []*model.WorkflowStepInput) []domain.WorkflowStep {
+	steps := make([]domain.WorkflowStep, 0, len(input))
+	for _, step := range input {
+		var method string
+		if step.Method != nil {
+			method = step.Method.String()
+		}
+
+		headers := make([]domain.WorkflowHeader, 0, len(step.Headers))
+		for _, header := range step.Headers {
+			headers = append(headers, domain.WorkflowHeader{
+				Name:  header.Name,
+				Value: header.Value,
+			})
+		}
+
+		variables := make([]domain.WorkflowVariable, 0, len(step.Variables))
+		for _, variable := range step.Variables {
+			variables = append(variables, domain.WorkflowVariable{
+				Name:     variable.Name,
+				JSONPath: transform.ToValueOrDefault(variable.JSONPath, ""),
+				Regex:    transform.ToValueOrDefault(variable.Regex, ""),
+			})
+		}
+
+		steps = append(steps, domain.WorkflowStep{
+			Name:      transform.ToValueOrDefault(step.Name, ""),
+			Method:    method,
+			URL:       transform.ToValueOrDefault(step.URL, ""),
+			Headers:   headers,
+			Body:      transform.ToValueOrDefault(step.Body, ""),
+			Variables: variables,
+		})
+	}
+	return steps
+}
+
+func buildIgnoreOption(input *model.IgnoreOptionInput) domain.IgnoreOption {
+	if input == nil {
+		return domain.IgnoreOption{}
+	}
+
+	rules := make([]domain.IgnoreRule, 0, len(input.Rules))
+	for _, rule := range input.Rules {
+		rules = append(rules, domain.IgnoreRule{
+			Pattern:     rule.Pattern,
+			Replacement: transform.ToValueOrDefault(rule.Replacement, ""),
+		})
+	}
+
+	return domain.IgnoreOption{
+		Selectors: input.Selectors,
+		Rules:     rules,
+		Presets:   input.Presets,
+	}
+}
+
+func buildRecordOption(input *model.RecordOptionInput) domain.RecordOption {
+	if input == nil {
+		return domain.RecordOption{}
+	}
+
+	fields := make([]domain.RecordField, 0, len(input.Fields))
+	for _, field := range input.Fields {
+		fields = append(fields, domain.RecordField{
+			Name:     field.Name,
+			Selector: transform.ToValueOrDefault(field.Selector, ""),
+		})
+	}
+
+	return domain.RecordOption{
+		RowSelector: input.RowSelector,
+		Fields:      fields,
+	}
+}
+
+func buildTableOption(input *model.TableOptionInput) domain.TableOption {
+	if input == nil {
+		return domain.TableOption{}
+	}
+
+	return domain.TableOption{
+		Selector:   input.Selector,
+		Format:     transform.ToValueOrDefault(input.Format, domain.TableFormatJSON),
+		KeyColumns: input.KeyColumns,
+	}
+}
+
+func buildConvertOption(input *model.ConvertOptionInput) domain.ConvertOption {
+	if input == nil {
+		return domain.ConvertOption{}
+	}
+
+	return domain.ConvertOption{
+		Format:      input.Format,
+		Readability: transform.ToValueOrDefault(input.Readability, false),
+	}
+}
+
+func buildXMLOption(input *model.XMLOptionInput) domain.XMLOption {
+	if input == nil {
+		return domain.XMLOption{}
+	}
+
+	namespaces := make([]domain.XMLNamespace, 0, len(input.Namespaces))
+	for _, ns := range input.Namespaces {
+		namespaces = append(namespaces, domain.XMLNamespace{
+			Prefix: ns.Prefix,
+			URI:    ns.URI,
+		})
+	}
+
+	return domain.XMLOption{
+		Enabled:    transform.ToValueOrDefault(input.Enabled, false),
+		XPath:      input.Xpath,
+		Namespaces: namespaces,
+	}
+}
+
+func buildCSVOption(input *model.CSVOptionInput) domain.CSVOption {
+	if input == nil {
+		return domain.CSVOption{}
+	}
+
+	filters := make([]domain.CSVFilter, 0, len(input.Filters))
+	for _, filter := range input.Filters {
+		filters = append(filters, domain.CSVFilter{
+			Column:  filter.Column,
+			Pattern: filter.Pattern,
+		})
+	}
+
+	return domain.CSVOption{
+		Enabled:    transform.ToValueOrDefault(input.Enabled, false),
+		Delimiter:  transform.ToValueOrDefault(input.Delimiter, ""),
+		Columns:    input.Columns,
+		Filters:    filters,
+		KeyColumns: input.KeyColumns,
+	}
+}
+
+func buildRegexOption(input *model.RegexOptionInput) domain.RegexOption {
+	if input == nil {
+		return domain.RegexOption{}
+	}
+
+	return domain.RegexOption{
+		Patterns: input.Patterns,
+		Mode:     transform.ToValueOrDefault(input.Mode, domain.RegexModeFirst),
+	}
+}
+
+func buildFilterOption(input *model.FilterOptionInput) domain.FilterOption {
+	if input == nil {
+		return domain.FilterOption{}
+	}
+
+	return domain.FilterOption{
+		Include:    input.Include,
+		Exclude:    input.Exclude,
+		Regex:      transform.ToValueOrDefault(input.Regex, false),
+		IgnoreCase: transform.ToValueOrDefault(input.IgnoreCase, false),
+	}
+}
+
+func buildDeduplicationOption(input *model.DeduplicationOptionInput) domain.DeduplicationOption {
+	if input == nil {
+		return domain.DeduplicationOption{}
+	}
+
+	return domain.DeduplicationOption{
+		Mode:                transform.ToValueOrDefault(input.Mode, domain.DeduplicationModeLine),
+		Delimiter:           transform.ToValueOrDefault(input.Delimiter, ""),
+		IgnoreCase:          transform.ToValueOrDefault(input.IgnoreCase, false),
+		NormalizeWhitespace: transform.ToValueOrDefault(input.NormalizeWhitespace, false),
+	}
+}
+
+func buildSortOption(input *model.SortOptionInput) domain.SortOption {
+	if input == nil {
+		return domain.SortOption{}
+	}
+
+	return domain.SortOption{
+		Mode:       transform.ToValueOrDefault(input.Mode, domain.SortModeLexical),
+		Reverse:    transform.ToValueOrDefault(input.Reverse, false),
+		KeyPattern: transform.ToValueOrDefault(input.KeyPattern, ""),
+	}
+}
+
+func buildPaginationOption(input *model.PaginationOptionInput) domain.PaginationOption {
+	if input == nil {
+		return domain.PaginationOption{}
+	}
+
+	return domain.PaginationOption{
+		NextSelector: transform.ToValueOrDefault(input.NextSelector, ""),
+		NextJSONPath: transform.ToValueOrDefault(input.NextJSONPath, ""),
+		CursorParam:  transform.ToValueOrDefault(input.CursorParam, ""),
+		PageParam:    transform.ToValueOrDefault(input.PageParam, ""),
+		StartPage:    input.StartPage,
+		MaxPages:     transform.ToValueOrDefault(input.MaxPages, 0),
+		ItemSelector: transform.ToValueOrDefault(input.ItemSelector, ""),
+	}
+}
+
+func buildCrawlOption(input *model.CrawlOptionInput) domain.CrawlOption {
+	if input == nil {
+		return domain.CrawlOption{}
+	}
+
+	return domain.CrawlOption{
+		MaxDepth: input.MaxDepth,
+		MaxPages: transform.ToValueOrDefault(input.MaxPages, 0),
+		Include:  input.Include,
+		Exclude:  input.Exclude,
+	}
+}
+
+func buildExecOption(input *model.ExecOptionInput) domain.ExecOption {
+	if input == nil {
+		return domain.ExecOption{}
+	}
+
+	return domain.ExecOption{
+		Command: input.Command,
+		Args:    input.Args,
+		Env:     input.Env,
+		Dir:     transform.ToValueOrDefault(input.Dir, ""),
+		Timeout: input.Timeout,
+	}
+}
+
+func buildTLSOption(input *model.TLSOptionInput) domain.TLSOption {
+	if input == nil {
+		return domain.TLSOption{}
+	}
+
+	return domain.TLSOption{
+		CACertificates:     input.CaCertificates,
+		ClientCertificate:  transform.ToValueOrDefault(input.ClientCertificate, ""),
+		ClientKey:          transform.ToValueOrDefault(input.ClientKey, ""),
+		MinVersion:         transform.ToValueOrDefault(input.MinVersion, ""),
+		InsecureSkipVerify: transform.ToValueOrDefault(input.InsecureSkipVerify, false),
+	}
+}
+
+// updateSetting builds the setting of an updated website. The client key is never returned by the API,
+// so the stored one is kept when the TLS input doesn't set it.
+func updateSetting(input *model.SettingInput, previous domain.Setting) domain.Setting {
+	setting := buildSetting(input)
+	if input != nil && input.TLS != nil && input.TLS.ClientKey == nil {
+		setting.TLS.ClientKey = previous.TLS.ClientKey
+	}
+	return setting
+}
+
+func buildPreview(trace domain.PipelineTrace) *model.WebsitePreview {
+	stages := make([]*model.PreviewStage, 0, len(trace.Stages))
+	for _, stage := range trace.Stages {
+		preview := &model.PreviewStage{
+			Type:      stage.Type,
+			Output:    string(stage.Output),
+			Size:      len(stage.Output),
+			ElapsedMs: float64(stage.Elapsed) / float64(time.Millisecond),
+		}
+		if stage.Error != "" {
+			preview.Error = transform.ToPtr(stage.Error)
+		}
+		stages = append(stages, preview)
+	}
+
+	return &model.WebsitePreview{
+		Result: string(trace.Result),
+		Raw:    string(trace.Raw),
+		Stages: stages,
 	}
 }
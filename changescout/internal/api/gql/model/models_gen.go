@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/gelleson/changescout/changescout/internal/app/services/diff"
 	"github.com/gelleson/changescout/changescout/internal/domain"
 	"github.com/gelleson/changescout/changescout/pkg/crons"
 	"github.com/google/uuid"
@@ -31,6 +32,68 @@ type AuthStatus struct {
 	RefreshToken *string `json:"refreshToken,omitempty"`
 }
 
+type CSVFilterInput struct {
+	Column  string `json:"column"`
+	Pattern string `json:"pattern"`
+}
+
+type CSVOptionInput struct {
+	Enabled    *bool             `json:"enabled,omitempty"`
+	Delimiter  *string           `json:"delimiter,omitempty"`
+	Columns    []string          `json:"columns,omitempty"`
+	Filters    []*CSVFilterInput `json:"filters,omitempty"`
+	KeyColumns []string          `json:"key_columns,omitempty"`
+}
+
+type ConvertOptionInput struct {
+	Format      domain.ConvertFormat `json:"format"`
+	Readability *bool                `json:"readability,omitempty"`
+}
+
+type CrawlOptionInput struct {
+	MaxDepth *int     `json:"max_depth,omitempty"`
+	MaxPages *int     `json:"max_pages,omitempty"`
+	Include  []string `json:"include,omitempty"`
+	Exclude  []string `json:"exclude,omitempty"`
+}
+
+type DeduplicationOptionInput struct {
+	Mode                *domain.DeduplicationMode `json:"mode,omitempty"`
+	Delimiter           *string                   `json:"delimiter,omitempty"`
+	IgnoreCase          *bool                     `json:"ignore_case,omitempty"`
+	NormalizeWhitespace *bool                     `json:"normalize_whitespace,omitempty"`
+}
+
+type DiffOptionInput struct {
+	JSONArrayKey *string `json:"json_array_key,omitempty"`
+}
+
+type ExecOptionInput struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+	Env     []string `json:"env,omitempty"`
+	Dir     *string  `json:"dir,omitempty"`
+	Timeout *int     `json:"timeout,omitempty"`
+}
+
+type FilterOptionInput struct {
+	Include    []string `json:"include,omitempty"`
+	Exclude    []string `json:"exclude,omitempty"`
+	Regex      *bool    `json:"regex,omitempty"`
+	IgnoreCase *bool    `json:"ignore_case,omitempty"`
+}
+
+type IgnoreOptionInput struct {
+	Selectors []string              `json:"selectors,omitempty"`
+	Rules     []*IgnoreRuleInput    `json:"rules,omitempty"`
+	Presets   []domain.IgnorePreset `json:"presets,omitempty"`
+}
+
+type IgnoreRuleInput struct {
+	Pattern     string  `json:"pattern"`
+	Replacement *string `json:"replacement,omitempty"`
+}
+
 type Mutation struct {
 }
 
@@ -57,19 +120,141 @@ type NotificationUpdateInput struct {
 	WebsiteID   *uuid.UUID              `json:"websiteId,omitempty"`
 }
 
+type NumericOptionInput struct {
+	Enabled      *bool    `json:"enabled,omitempty"`
+	Selector     *string  `json:"selector,omitempty"`
+	JSONPath     *string  `json:"json_path,omitempty"`
+	Regex        *string  `json:"regex,omitempty"`
+	Locale       *string  `json:"locale,omitempty"`
+	Above        *float64 `json:"above,omitempty"`
+	Below        *float64 `json:"below,omitempty"`
+	DeltaPercent *float64 `json:"delta_percent,omitempty"`
+}
+
+type PaginationOptionInput struct {
+	NextSelector *string `json:"next_selector,omitempty"`
+	NextJSONPath *string `json:"next_json_path,omitempty"`
+	CursorParam  *string `json:"cursor_param,omitempty"`
+	PageParam    *string `json:"page_param,omitempty"`
+	StartPage    *int    `json:"start_page,omitempty"`
+	MaxPages     *int    `json:"max_pages,omitempty"`
+	ItemSelector *string `json:"item_selector,omitempty"`
+}
+
+type PreviewStage struct {
+	Type      domain.StepType `json:"type"`
+	Output    string          `json:"output"`
+	Size      int             `json:"size"`
+	ElapsedMs float64         `json:"elapsed_ms"`
+	Error     *string         `json:"error,omitempty"`
+}
+
+type ProcessingStepInput struct {
+	Type          domain.StepType           `json:"type"`
+	Ignore        *IgnoreOptionInput        `json:"ignore,omitempty"`
+	Selectors     []string                  `json:"selectors,omitempty"`
+	Records       *RecordOptionInput        `json:"records,omitempty"`
+	Table         *TableOptionInput         `json:"table,omitempty"`
+	Convert       *ConvertOptionInput       `json:"convert,omitempty"`
+	Xpath         []string                  `json:"xpath,omitempty"`
+	XML           *XMLOptionInput           `json:"xml,omitempty"`
+	JSONPath      []string                  `json:"json_path,omitempty"`
+	Jq            *string                   `json:"jq,omitempty"`
+	CSV           *CSVOptionInput           `json:"csv,omitempty"`
+	Regex         *RegexOptionInput         `json:"regex,omitempty"`
+	Filter        *FilterOptionInput        `json:"filter,omitempty"`
+	Script        *string                   `json:"script,omitempty"`
+	Plugin        *string                   `json:"plugin,omitempty"`
+	Deduplication *DeduplicationOptionInput `json:"deduplication,omitempty"`
+	Sort          *SortOptionInput          `json:"sort,omitempty"`
+}
+
 type Query struct {
 }
 
+type RecordFieldInput struct {
+	Name     string  `json:"name"`
+	Selector *string `json:"selector,omitempty"`
+}
+
+type RecordOptionInput struct {
+	RowSelector string              `json:"row_selector"`
+	Fields      []*RecordFieldInput `json:"fields"`
+}
+
+type RegexOptionInput struct {
+	Patterns []string          `json:"patterns"`
+	Mode     *domain.RegexMode `json:"mode,omitempty"`
+}
+
 type SettingInput struct {
-	UserAgent     *string  `json:"user_agent,omitempty"`
-	Referer       *string  `json:"referer,omitempty"`
-	Method        Method   `json:"method"`
-	Template      *string  `json:"template,omitempty"`
-	Deduplication *bool    `json:"deduplication,omitempty"`
-	Trim          *bool    `json:"trim,omitempty"`
-	Sort          *bool    `json:"sort,omitempty"`
-	Selectors     []string `json:"selectors,omitempty"`
-	JSONPath      []string `json:"json_path,omitempty"`
+	UserAgent           *string                   `json:"user_agent,omitempty"`
+	Referer             *string                   `json:"referer,omitempty"`
+	Method              Method                    `json:"method"`
+	Body                *string                   `json:"body,omitempty"`
+	Template            *string                   `json:"template,omitempty"`
+	Pipeline            []*ProcessingStepInput    `json:"pipeline,omitempty"`
+	Deduplication       *bool                     `json:"deduplication,omitempty"`
+	DeduplicationOption *DeduplicationOptionInput `json:"deduplication_option,omitempty"`
+	Trim                *bool                     `json:"trim,omitempty"`
+	Sort                *bool                     `json:"sort,omitempty"`
+	SortOption          *SortOptionInput          `json:"sort_option,omitempty"`
+	Ignore              *IgnoreOptionInput        `json:"ignore,omitempty"`
+	Selectors           []string                  `json:"selectors,omitempty"`
+	Records             *RecordOptionInput        `json:"records,omitempty"`
+	Table               *TableOptionInput         `json:"table,omitempty"`
+	Convert             *ConvertOptionInput       `json:"convert,omitempty"`
+	Xpath               []string                  `json:"xpath,omitempty"`
+	JSONPath            []string                  `json:"json_path,omitempty"`
+	Jq                  *string                   `json:"jq,omitempty"`
+	Script              *string                   `json:"script,omitempty"`
+	Plugin              *string                   `json:"plugin,omitempty"`
+	XML                 *XMLOptionInput           `json:"xml,omitempty"`
+	CSV                 *CSVOptionInput           `json:"csv,omitempty"`
+	Regex               *RegexOptionInput         `json:"regex,omitempty"`
+	Filter              *FilterOptionInput        `json:"filter,omitempty"`
+	TLS                 *TLSOptionInput           `json:"tls,omitempty"`
+	Exec                *ExecOptionInput          `json:"exec,omitempty"`
+	Crawl               *CrawlOptionInput         `json:"crawl,omitempty"`
+	Pagination          *PaginationOptionInput    `json:"pagination,omitempty"`
+	Workflow            []*WorkflowStepInput      `json:"workflow,omitempty"`
+	Diff                *DiffOptionInput          `json:"diff,omitempty"`
+	Threshold           *ThresholdOptionInput     `json:"threshold,omitempty"`
+	Numeric             *NumericOptionInput       `json:"numeric,omitempty"`
+	Triggers            []*TriggerInput           `json:"triggers,omitempty"`
+}
+
+type SortOptionInput struct {
+	Mode       *domain.SortMode `json:"mode,omitempty"`
+	Reverse    *bool            `json:"reverse,omitempty"`
+	KeyPattern *string          `json:"key_pattern,omitempty"`
+}
+
+type TLSOptionInput struct {
+	CaCertificates     []string `json:"ca_certificates,omitempty"`
+	ClientCertificate  *string  `json:"client_certificate,omitempty"`
+	ClientKey          *string  `json:"client_key,omitempty"`
+	MinVersion         *string  `json:"min_version,omitempty"`
+	InsecureSkipVerify *bool    `json:"insecure_skip_verify,omitempty"`
+}
+
+type TableOptionInput struct {
+	Selector   string              `json:"selector"`
+	Format     *domain.TableFormat `json:"format,omitempty"`
+	KeyColumns []string            `json:"key_columns,omitempty"`
+}
+
+type ThresholdOptionInput struct {
+	MinChangePercent *float64          `json:"min_change_percent,omitempty"`
+	MinChangedChars  *int              `json:"min_changed_chars,omitempty"`
+	MinChangedLines  *int              `json:"min_changed_lines,omitempty"`
+	ChangeTypes      []diff.ChangeType `json:"change_types,omitempty"`
+}
+
+type TriggerInput struct {
+	Type       domain.TriggerType `json:"type"`
+	Value      string             `json:"value"`
+	IgnoreCase *bool              `json:"ignore_case,omitempty"`
 }
 
 type WebsiteCreateInput struct {
@@ -82,7 +267,9 @@ type WebsiteCreateInput struct {
 }
 
 type WebsitePreview struct {
-	Result string `json:"result"`
+	Result string          `json:"result"`
+	Raw    string          `json:"raw"`
+	Stages []*PreviewStage `json:"stages"`
 }
 
 type WebsiteUpdateInput struct {
@@ -95,6 +282,37 @@ type WebsiteUpdateInput struct {
 	Setting *SettingInput         `json:"setting,omitempty"`
 }
 
+type WorkflowHeaderInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type WorkflowStepInput struct {
+	Name      *string                  `json:"name,omitempty"`
+	Method    *Method                  `json:"method,omitempty"`
+	URL       *string                  `json:"url,omitempty"`
+	Headers   []*WorkflowHeaderInput   `json:"headers,omitempty"`
+	Body      *string                  `json:"body,omitempty"`
+	Variables []*WorkflowVariableInput `json:"variables,omitempty"`
+}
+
+type WorkflowVariableInput struct {
+	Name     string  `json:"name"`
+	JSONPath *string `json:"json_path,omitempty"`
+	Regex    *string `json:"regex,omitempty"`
+}
+
+type XMLNamespaceInput struct {
+	Prefix string `json:"prefix"`
+	URI    string `json:"uri"`
+}
+
+type XMLOptionInput struct {
+	Enabled    *bool                `json:"enabled,omitempty"`
+	Xpath      []string             `json:"xpath,omitempty"`
+	Namespaces []*XMLNamespaceInput `json:"namespaces,omitempty"`
+}
+
 type Method string
 
 const (
@@ -5,6 +5,7 @@ import (
 	"github.com/gelleson/changescout/changescout/internal/app/usecases"
 	"github.com/gelleson/changescout/changescout/internal/app/usecases/auth"
 	"github.com/gelleson/changescout/changescout/internal/app/usecases/check"
+	"github.com/gelleson/changescout/changescout/internal/pkg/ratelimit"
 )
 
 // This file will not be regenerated automatically.
@@ -16,4 +17,5 @@ type Resolver struct {
 	AuthUseCase         *auth.UseCase
 	NotificationService *services.NotificationService
 	CheckUseCase        *check.UseCase
+	PreviewLimiter      *ratelimit.Limiter
 }
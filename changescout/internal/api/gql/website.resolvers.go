@@ -6,10 +6,12 @@ package gql
 
 import (
 	"context"
+	"time"
 
 	"github.com/gelleson/changescout/changescout/internal/api/gql/model"
 	"github.com/gelleson/changescout/changescout/internal/domain"
 	"github.com/gelleson/changescout/changescout/internal/pkg/contexts"
+	"github.com/gelleson/changescout/changescout/internal/pkg/ratelimit"
 	"github.com/gelleson/changescout/changescout/internal/platform/logger"
 	"github.com/gelleson/changescout/changescout/internal/utils/transform"
 	"github.com/gelleson/changescout/changescout/pkg/diff"
@@ -21,15 +23,7 @@ import (
 func (r *mutationResolver) CreateWebsite(ctx context.Context, input model.WebsiteCreateInput) (*domain.Website, error) {
 	log := logger.FromContext(ctx)
 	user, _ := contexts.UserContext(ctx)
-	site, err := r.WebsiteUseCase.Create(ctx, user.ID, domain.Website{
-		URL:     input.URL,
-		Name:    input.Name,
-		Enabled: input.Enabled,
-		Mode:    input.Mode,
-		Cron:    input.Cron,
-		Setting: buildSetting(input.Setting),
-		UserID:  user.ID,
-	})
+	site, err := r.WebsiteUseCase.Create(ctx, user.ID, buildWebsite(input, user.ID))
 	if err != nil {
 		log.Error(
 			"failed to create website",
@@ -60,7 +54,7 @@ func (r *mutationResolver) UpdateWebsite(ctx context.Context, input model.Websit
 		Enabled:     diff.GetUpdatedValue(input.Enabled, site.Enabled),
 		Mode:        diff.GetUpdatedValue(input.Mode, site.Mode),
 		Cron:        diff.GetUpdatedValue(input.Cron, site.Cron),
-		Setting:     buildSetting(input.Setting),
+		Setting:     updateSetting(input.Setting, site.Setting),
 		UserID:      site.UserID,
 		LastCheckAt: site.LastCheckAt,
 	})
@@ -94,14 +88,37 @@ func (r *mutationResolver) DeleteWebsite(ctx context.Context, id uuid.UUID) (boo
 
 // CreatePreviewWebsite is the resolver for the createPreviewWebsite field.
 func (r *mutationResolver) CreatePreviewWebsite(ctx context.Context, url uuid.UUID) (*model.WebsitePreview, error) {
-	preview, err := r.CheckUseCase.View(ctx, url)
+	user, _ := contexts.UserContext(ctx)
+	if !r.PreviewLimiter.Allow(user.ID.String()) {
+		return nil, ratelimit.ErrLimitExceeded
+	}
+
+	site, err := r.WebsiteUseCase.GetByID(ctx, user.ID, url)
+	if err != nil {
+		return nil, err
+	}
+
+	trace, err := r.CheckUseCase.Debug(site)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPreview(trace), nil
+}
+
+// PreviewWebsite is the resolver for the previewWebsite field.
+func (r *mutationResolver) PreviewWebsite(ctx context.Context, input model.WebsiteCreateInput) (*model.WebsitePreview, error) {
+	user, _ := contexts.UserContext(ctx)
+	if !r.PreviewLimiter.Allow(user.ID.String()) {
+		return nil, ratelimit.ErrLimitExceeded
+	}
+
+	trace, err := r.CheckUseCase.Preview(buildWebsite(input, user.ID))
 	if err != nil {
 		return nil, err
 	}
 
-	return &model.WebsitePreview{
-		Result: string(preview),
-	}, nil
+	return buildPreview(trace), nil
 }
 
 // GetWebsiteByID is the resolver for the getWebsiteByID field.
@@ -135,3 +152,19 @@ func (r *queryResolver) GetWebsites(ctx context.Context) ([]*domain.Website, err
 		return &t
 	}), nil
 }
+
+// GetWebsiteSeries is the resolver for the getWebsiteSeries field.
+func (r *queryResolver) GetWebsiteSeries(ctx context.Context, id uuid.UUID, from *time.Time, to *time.Time) ([]*domain.SeriesPoint, error) {
+	user, _ := contexts.UserContext(ctx)
+	if _, err := r.WebsiteUseCase.GetByID(ctx, user.ID, id); err != nil {
+		return nil, err
+	}
+
+	points, err := r.CheckUseCase.Series(ctx, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return transform.MapObjects(points, func(t domain.SeriesPoint) *domain.SeriesPoint {
+		return &t
+	}), nil
+}
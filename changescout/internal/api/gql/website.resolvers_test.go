@@ -0,0 +1,40 @@
+package gql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/api/gql/model"
+	"github.com/gelleson/changescout/changescout/internal/app/usecases/check"
+	"github.com/gelleson/changescout/changescout/internal/app/usecases/check/mocks"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/contexts"
+	"github.com/gelleson/changescout/changescout/internal/pkg/ratelimit"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewWebsite_WithoutSetting(t *testing.T) {
+	websiteService := mocks.NewWebsiteService(t)
+	httpService := mocks.NewHttpService(t)
+	websiteService.On("Validate", mock.Anything).Return(nil)
+	httpService.On("Request", mock.Anything).Return([]byte("content"), nil)
+
+	resolver := &mutationResolver{&Resolver{
+		CheckUseCase:   check.NewUseCase(websiteService, httpService, mocks.NewDBService(t), mocks.NewDiffService(t), nil),
+		PreviewLimiter: ratelimit.New(0),
+	}}
+	ctx := contexts.WithUserContext(context.Background(), &domain.AuthClaims{ID: uuid.New()})
+
+	preview, err := resolver.PreviewWebsite(ctx, model.WebsiteCreateInput{
+		Name: "Example",
+		URL:  "https://example.com",
+		Mode: domain.ModePlain,
+		Cron: "*/15 * * * *",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "content", preview.Result)
+}
@@ -0,0 +1,21 @@
+package middlewares
+
+import (
+	"github.com/gelleson/changescout/changescout/internal/pkg/contexts"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// RequireAuth rejects the requests without the user set by JWTAuth.
+func RequireAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := contexts.UserContext(c.Request().Context())
+			if !ok || user.ID == uuid.Nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "not authenticated")
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"context"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/contexts"
+	"github.com/gelleson/changescout/changescout/internal/pkg/ratelimit"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+//go:generate mockery --name PreviewUseCase
+type PreviewUseCase interface {
+	Debug(site domain.Website) (domain.PipelineTrace, error)
+}
+
+//go:generate mockery --name WebsiteUseCase
+type WebsiteUseCase interface {
+	GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (domain.Website, error)
+}
+
+type PreviewHandler struct {
+	websiteUseCase WebsiteUseCase
+	useCase        PreviewUseCase
+	limiter        *ratelimit.Limiter
+}
+
+func NewPreviewHandler(websiteUseCase WebsiteUseCase, useCase PreviewUseCase, limiter *ratelimit.Limiter) *PreviewHandler {
+	return &PreviewHandler{websiteUseCase: websiteUseCase, useCase: useCase, limiter: limiter}
+}
+
+type previewResponse struct {
+	Raw    string          `json:"raw"`
+	Stages []stageResponse `json:"stages"`
+	Result string          `json:"result"`
+}
+
+type stageResponse struct {
+	Type      domain.StepType `json:"type"`
+	Output    string          `json:"output"`
+	Size      int             `json:"size"`
+	ElapsedMs float64         `json:"elapsed_ms"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// Preview returns the fetched content of the website and the output of every processing stage.
+func (h *PreviewHandler) Preview(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid website id")
+	}
+
+	user, ok := contexts.UserContext(c.Request().Context())
+	if !ok || user == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, domain.ErrInvalidToken.Error())
+	}
+	if !h.limiter.Allow(user.ID.String()) {
+		return echo.NewHTTPError(http.StatusTooManyRequests, ratelimit.ErrLimitExceeded.Error())
+	}
+
+	// Only the owner can preview the website, the others get the same answer as for a missing one
+	site, err := h.websiteUseCase.GetByID(c.Request().Context(), user.ID, id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, domain.ErrWebsiteNotFound.Error())
+	}
+
+	trace, err := h.useCase.Debug(site)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, buildPreviewResponse(trace))
+}
+
+func buildPreviewResponse(trace domain.PipelineTrace) previewResponse {
+	stages := make([]stageResponse, 0, len(trace.Stages))
+	for _, stage := range trace.Stages {
+		stages = append(stages, stageResponse{
+			Type:      stage.Type,
+			Output:    string(stage.Output),
+			Size:      len(stage.Output),
+			ElapsedMs: float64(stage.Elapsed) / float64(time.Millisecond),
+			Error:     stage.Error,
+		})
+	}
+
+	return previewResponse{
+		Raw:    string(trace.Raw),
+		Stages: stages,
+		Result: string(trace.Result),
+	}
+}
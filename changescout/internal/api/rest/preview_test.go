@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gelleson/changescout/changescout/internal/api/rest/mocks"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/contexts"
+	"github.com/gelleson/changescout/changescout/internal/pkg/ratelimit"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPreviewHandler_PreviewRateLimit(t *testing.T) {
+	websiteID := uuid.New()
+	user := &domain.AuthClaims{ID: uuid.New()}
+	site := domain.Website{ID: websiteID, UserID: user.ID}
+	websiteUseCase := mocks.NewWebsiteUseCase(t)
+	websiteUseCase.On("GetByID", mock.Anything, user.ID, websiteID).Return(site, nil).Once()
+	useCase := mocks.NewPreviewUseCase(t)
+	useCase.On("Debug", site).Return(domain.PipelineTrace{}, nil).Once()
+	handler := NewPreviewHandler(websiteUseCase, useCase, ratelimit.New(1))
+
+	e := echo.New()
+	preview := func() error {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(contexts.WithUserContext(req.Context(), user))
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.SetParamNames("id")
+		c.SetParamValues(websiteID.String())
+		return handler.Preview(c)
+	}
+
+	assert.NoError(t, preview())
+
+	var httpErr *echo.HTTPError
+	assert.ErrorAs(t, preview(), &httpErr)
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.Code)
+}
+
+func TestPreviewHandler_Preview(t *testing.T) {
+	websiteID := uuid.New()
+	user := &domain.AuthClaims{ID: uuid.New()}
+	site := domain.Website{ID: websiteID, UserID: user.ID}
+	trace := domain.PipelineTrace{
+		Raw: []byte("<h1>Title</h1>"),
+		Stages: []domain.StageTrace{
+			{Type: domain.StepHTML, Output: []byte("Title"), Elapsed: 2 * time.Millisecond},
+			{Type: domain.StepRegex, Error: "no matches"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		mock       func(websiteUseCase *mocks.WebsiteUseCase, useCase *mocks.PreviewUseCase)
+		wantStatus int
+		expected   *previewResponse
+	}{
+		{
+			name: "Trace",
+			id:   websiteID.String(),
+			mock: func(websiteUseCase *mocks.WebsiteUseCase, useCase *mocks.PreviewUseCase) {
+				websiteUseCase.On("GetByID", mock.Anything, user.ID, websiteID).Return(site, nil)
+				useCase.On("Debug", site).Return(trace, nil)
+			},
+			wantStatus: http.StatusOK,
+			expected: &previewResponse{
+				Raw: "<h1>Title</h1>",
+				Stages: []stageResponse{
+					{Type: domain.StepHTML, Output: "Title", Size: 5, ElapsedMs: 2},
+					{Type: domain.StepRegex, Error: "no matches"},
+				},
+			},
+		},
+		{
+			name:       "Invalid id",
+			id:         "invalid",
+			mock:       func(websiteUseCase *mocks.WebsiteUseCase, useCase *mocks.PreviewUseCase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Website of another user",
+			id:   websiteID.String(),
+			mock: func(websiteUseCase *mocks.WebsiteUseCase, useCase *mocks.PreviewUseCase) {
+				websiteUseCase.On("GetByID", mock.Anything, user.ID, websiteID).Return(domain.Website{}, domain.ErrWebsiteNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Request failed",
+			id:   websiteID.String(),
+			mock: func(websiteUseCase *mocks.WebsiteUseCase, useCase *mocks.PreviewUseCase) {
+				websiteUseCase.On("GetByID", mock.Anything, user.ID, websiteID).Return(site, nil)
+				useCase.On("Debug", site).Return(domain.PipelineTrace{}, errors.New("request failed"))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			websiteUseCase := mocks.NewWebsiteUseCase(t)
+			useCase := mocks.NewPreviewUseCase(t)
+			test.mock(websiteUseCase, useCase)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(contexts.WithUserContext(req.Context(), user))
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(test.id)
+
+			err := NewPreviewHandler(websiteUseCase, useCase, nil).Preview(c)
+			if test.expected == nil {
+				var httpErr *echo.HTTPError
+				assert.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, test.wantStatus, httpErr.Code)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantStatus, rec.Code)
+
+			var actual previewResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+			assert.Equal(t, *test.expected, actual)
+		})
+	}
+}
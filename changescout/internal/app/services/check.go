@@ -46,4 +46,8 @@ func (s CheckService) GetCheckByID(ctx context.Context, id uuid.UUID) (domain.Ch
 	return s.repository.GetCheckByID(ctx, id)
 }
 
+func (s CheckService) GetSeries(ctx context.Context, filters database.CheckFilters) ([]domain.SeriesPoint, error) {
+	return s.repository.GetSeries(ctx, filters)
+}
+
 var _ database.CheckRepository = (*CheckService)(nil)
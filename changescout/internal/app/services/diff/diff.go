@@ -28,6 +28,9 @@ type Change struct {
 	Type    ChangeType `json:"type"`
 	Content string     `json:"content"`
 	Path    string     `json:"path"`
+	// OldValue and NewValue are the canonical JSON values of the structural JSON changes.
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
 }
 
 type ChangeType string
@@ -89,6 +92,10 @@ func (s *Service) Compare(previous, current []byte) (Result, error) {
 		return result, nil
 	}
 
+	if isJSON(previous) && isJSON(current) {
+		return s.CompareJSON(previous, current, "")
+	}
+
 	isHTMLContent := len(previous) > 9 && (bytes.Equal(bytes.ToLower(previous[:9]), []byte("<!doctype")) ||
 		bytes.Contains(previous[:min(100, len(previous))], []byte("<html>")))
 
@@ -112,6 +119,7 @@ func (s *Service) compareText(previous, current []byte) (Result, error) {
 	totalLen := float64(len(previous) + len(current))
 	changedLen := 0.0
 
+	// Every changed line is a change of its own, so the changes can be counted by line
 	var buf strings.Builder
 	for _, diff := range diffs {
 		lines := strings.Split(diff.Text, "\n")
@@ -124,16 +132,16 @@ func (s *Service) compareText(previous, current []byte) (Result, error) {
 				buf.WriteString(line)
 				changes = append(changes, Change{
 					Type:    Added,
-					Content: diff.Text,
+					Content: line,
 				})
-				changedLen += float64(len(diff.Text))
+				changedLen += float64(len(line))
 			case diffmatchpatch.DiffDelete:
 				buf.WriteString(line)
 				changes = append(changes, Change{
 					Type:    Removed,
-					Content: diff.Text,
+					Content: line,
 				})
-				changedLen += float64(len(diff.Text))
+				changedLen += float64(len(line))
 			case diffmatchpatch.DiffEqual:
 				buf.WriteString(line)
 			}
@@ -49,6 +49,19 @@ func (s *DiffTestSuite) TestTextContent() {
 	assert.NotEmpty(s.T(), result.Changes)
 }
 
+func (s *DiffTestSuite) TestTextContentChangePerLine() {
+	previous := []byte("a\n")
+	current := []byte("a\nx1\nx2\nx3\n")
+
+	result, err := s.service.Compare(previous, current)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []Change{
+		{Type: Added, Content: "x1"},
+		{Type: Added, Content: "x2"},
+		{Type: Added, Content: "x3"},
+	}, result.Changes)
+}
+
 func (s *DiffTestSuite) TestHTMLContent() {
 	previous := []byte(`<!DOCTYPE html><html><body><h1>Hello</h1></body></html>`)
 	current := []byte(`<!DOCTYPE html><html><body><h1>Hello Updated</h1></body></html>`)
@@ -0,0 +1,235 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pmezard/go-difflib/difflib"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CompareJSON compares two JSON documents structurally, so the formatting and the order of the keys don't matter.
+// Every change is reported in the RFC 6902 style with a JSON Pointer path: Added for "add",
+// Removed for "remove" and Modified for "replace". The elements of the arrays are matched by the
+// key field when every element is an object with the field, e.g. "id", and by the position otherwise.
+// The content which isn't JSON is compared as text.
+func (s *Service) CompareJSON(previous, current []byte, keyField string) (Result, error) {
+	prevDoc, prevErr := decodeJSON(previous)
+	currDoc, currErr := decodeJSON(current)
+	if prevErr != nil || currErr != nil {
+		return s.Compare(previous, current)
+	}
+
+	result := Result{
+		ChangedAt:    time.Now(),
+		PreviousHash: hash(previous),
+		CurrentHash:  hash(current),
+	}
+
+	changes := compareValues("", prevDoc, currDoc, keyField, nil)
+	result.HasChanges = len(changes) > 0
+	result.Changes = changes
+
+	if total := max(countValues(prevDoc), countValues(currDoc)); total > 0 {
+		result.ChangePercent = math.Min(float64(len(changes))/float64(total)*100, 100)
+	}
+
+	result.Diff, _ = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(canonicalJSON(prevDoc, "  ")),
+		B:        difflib.SplitLines(canonicalJSON(currDoc, "  ")),
+		FromFile: "Expected",
+		ToFile:   "Actual",
+		Context:  3,
+	})
+
+	return result, nil
+}
+
+func isJSON(content []byte) bool {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return false
+	}
+	return json.Valid(trimmed)
+}
+
+func decodeJSON(content []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	return doc, nil
+}
+
+func compareValues(path string, previous, current any, keyField string, changes []Change) []Change {
+	switch prev := previous.(type) {
+	case map[string]any:
+		if curr, ok := current.(map[string]any); ok {
+			return compareObjects(path, prev, curr, keyField, changes)
+		}
+	case []any:
+		if curr, ok := current.([]any); ok {
+			return compareArrays(path, prev, curr, keyField, changes)
+		}
+	}
+
+	if equalValues(previous, current) {
+		return changes
+	}
+	return append(changes, replaced(path, previous, current))
+}
+
+func compareObjects(path string, previous, current map[string]any, keyField string, changes []Change) []Change {
+	keys := make([]string, 0, len(previous)+len(current))
+	for key := range previous {
+		keys = append(keys, key)
+	}
+	for key := range current {
+		if _, ok := previous[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := path + "/" + escapePointer(key)
+		prev, hadPrev := previous[key]
+		curr, hasCurr := current[key]
+		switch {
+		case !hadPrev:
+			changes = append(changes, added(childPath, curr))
+		case !hasCurr:
+			changes = append(changes, removed(childPath, prev))
+		default:
+			changes = compareValues(childPath, prev, curr, keyField, changes)
+		}
+	}
+	return changes
+}
+
+func compareArrays(path string, previous, current []any, keyField string, changes []Change) []Change {
+	prevKeys, prevKeyed := arrayKeys(previous, keyField)
+	currKeys, currKeyed := arrayKeys(current, keyField)
+	if !prevKeyed || !currKeyed {
+		for i := 0; i < max(len(previous), len(current)); i++ {
+			childPath := path + "/" + strconv.Itoa(i)
+			switch {
+			case i >= len(previous):
+				changes = append(changes, added(childPath, current[i]))
+			case i >= len(current):
+				changes = append(changes, removed(childPath, previous[i]))
+			default:
+				changes = compareValues(childPath, previous[i], current[i], keyField, changes)
+			}
+		}
+		return changes
+	}
+
+	// The removed elements keep their previous index, the others get their current one
+	currIndex := make(map[string]int, len(currKeys))
+	for i, key := range currKeys {
+		currIndex[key] = i
+	}
+	prevIndex := make(map[string]int, len(prevKeys))
+	for i, key := range prevKeys {
+		prevIndex[key] = i
+		if _, ok := currIndex[key]; !ok {
+			changes = append(changes, removed(path+"/"+strconv.Itoa(i), previous[i]))
+		}
+	}
+	for i, key := range currKeys {
+		childPath := path + "/" + strconv.Itoa(i)
+		if j, ok := prevIndex[key]; ok {
+			changes = compareValues(childPath, previous[j], current[i], keyField, changes)
+		} else {
+			changes = append(changes, added(childPath, current[i]))
+		}
+	}
+	return changes
+}
+
+// arrayKeys returns the key field values of the elements, ok is false when an element has no unique key.
+func arrayKeys(elements []any, keyField string) ([]string, bool) {
+	if keyField == "" {
+		return nil, false
+	}
+
+	keys := make([]string, 0, len(elements))
+	seen := make(map[string]struct{}, len(elements))
+	for _, element := range elements {
+		object, ok := element.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		value, ok := object[keyField]
+		if !ok {
+			return nil, false
+		}
+		key := canonicalJSON(value, "")
+		if _, duplicated := seen[key]; duplicated {
+			return nil, false
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	return keys, true
+}
+
+func added(path string, value any) Change {
+	encoded := canonicalJSON(value, "")
+	return Change{Type: Added, Path: path, Content: encoded, NewValue: encoded}
+}
+
+func removed(path string, value any) Change {
+	encoded := canonicalJSON(value, "")
+	return Change{Type: Removed, Path: path, Content: encoded, OldValue: encoded}
+}
+
+func replaced(path string, previous, current any) Change {
+	encoded := canonicalJSON(current, "")
+	return Change{Type: Modified, Path: path, Content: encoded, OldValue: canonicalJSON(previous, ""), NewValue: encoded}
+}
+
+func equalValues(previous, current any) bool {
+	return canonicalJSON(previous, "") == canonicalJSON(current, "")
+}
+
+// canonicalJSON encodes the value with sorted keys and without HTML escaping.
+func canonicalJSON(value any, indent string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// countValues returns the number of the values of the document, the nested ones included.
+func countValues(value any) int {
+	count := 1
+	switch v := value.(type) {
+	case map[string]any:
+		for _, child := range v {
+			count += countValues(child)
+		}
+	case []any:
+		for _, child := range v {
+			count += countValues(child)
+		}
+	}
+	return count
+}
+
+// escapePointer escapes a JSON Pointer reference token as defined by RFC 6901.
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
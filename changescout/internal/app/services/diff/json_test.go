@@ -0,0 +1,67 @@
+package diff
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *DiffTestSuite) TestCompareJSON() {
+	previous := []byte(`{"name":"Widget","price":10,"tags":["a","b"],"stock":{"warehouse/1":5},"legacy":true}`)
+	current := []byte(`{
+		"name": "Widget",
+		"price": 12,
+		"tags": ["a", "b", "c"],
+		"stock": {"warehouse/1": 5},
+		"color": "red"
+	}`)
+
+	result, err := s.service.Compare(previous, current)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), result.HasChanges)
+	assert.Equal(s.T(), []Change{
+		{Type: Added, Path: "/color", Content: `"red"`, NewValue: `"red"`},
+		{Type: Removed, Path: "/legacy", Content: "true", OldValue: "true"},
+		{Type: Modified, Path: "/price", Content: "12", OldValue: "10", NewValue: "12"},
+		{Type: Added, Path: "/tags/2", Content: `"c"`, NewValue: `"c"`},
+	}, result.Changes)
+	assert.Contains(s.T(), result.Diff, `+  "price": 12,`)
+	assert.Greater(s.T(), result.ChangePercent, 0.0)
+}
+
+func (s *DiffTestSuite) TestCompareJSONFormattingOnly() {
+	previous := []byte(`{"b":1,"a":{"c":[1,2]}}`)
+	current := []byte("{\n  \"a\": {\"c\": [1, 2]},\n  \"b\": 1\n}")
+
+	result, err := s.service.Compare(previous, current)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), result.HasChanges)
+	assert.Empty(s.T(), result.Changes)
+}
+
+func (s *DiffTestSuite) TestCompareJSONArrayKey() {
+	previous := []byte(`{"items":[{"id":1,"price":5},{"id":2,"price":7},{"id":3,"price":9}]}`)
+	current := []byte(`{"items":[{"id":3,"price":9},{"id":1,"price":6},{"id":4,"price":1}]}`)
+
+	result, err := s.service.CompareJSON(previous, current, "id")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []Change{
+		{Type: Removed, Path: "/items/1", Content: `{"id":2,"price":7}`, OldValue: `{"id":2,"price":7}`},
+		{Type: Modified, Path: "/items/1/price", Content: "6", OldValue: "5", NewValue: "6"},
+		{Type: Added, Path: "/items/2", Content: `{"id":4,"price":1}`, NewValue: `{"id":4,"price":1}`},
+	}, result.Changes)
+
+	// Without the key the elements are matched by their position
+	result, err = s.service.CompareJSON(previous, current, "")
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), result.Changes, 6)
+}
+
+func (s *DiffTestSuite) TestCompareJSONNotJSON() {
+	result, err := s.service.CompareJSON([]byte("price: 10"), []byte("price: 12"), "id")
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), result.HasChanges)
+	assert.Empty(s.T(), result.Changes[0].Path)
+}
+
+func (s *DiffTestSuite) TestEscapePointer() {
+	assert.Equal(s.T(), "a~1b~0c", escapePointer("a/b~c"))
+}
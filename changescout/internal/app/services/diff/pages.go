@@ -0,0 +1,93 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pmezard/go-difflib/difflib"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ComparePages compares two JSON encoded maps of page URL to page content, as produced by the crawl mode.
+// Every added, removed or changed page is reported as a single Change with the page URL as the Path.
+func (s *Service) ComparePages(previous, current []byte) (Result, error) {
+	prevPages, err := decodePages(previous)
+	if err != nil {
+		return Result{}, err
+	}
+	currPages, err := decodePages(current)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{
+		ChangedAt:    time.Now(),
+		PreviousHash: hash(previous),
+		CurrentHash:  hash(current),
+	}
+
+	urls := make(map[string]struct{}, len(prevPages)+len(currPages))
+	for u := range prevPages {
+		urls[u] = struct{}{}
+	}
+	for u := range currPages {
+		urls[u] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(urls))
+	for u := range urls {
+		sorted = append(sorted, u)
+	}
+	sort.Strings(sorted)
+
+	var changes []Change
+	var buf strings.Builder
+	for _, u := range sorted {
+		prev, hadPrev := prevPages[u]
+		curr, hasCurr := currPages[u]
+
+		var change Change
+		switch {
+		case !hadPrev:
+			change = Change{Type: Added, Content: curr, Path: u}
+		case !hasCurr:
+			change = Change{Type: Removed, Content: prev, Path: u}
+		case prev != curr:
+			change = Change{Type: Modified, Content: curr, Path: u}
+		default:
+			continue
+		}
+		changes = append(changes, change)
+
+		pageDiff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(prev),
+			B:        difflib.SplitLines(curr),
+			FromFile: u,
+			ToFile:   u,
+			Context:  3,
+		})
+		buf.WriteString(fmt.Sprintf("%s %s\n", change.Type, u))
+		buf.WriteString(pageDiff)
+	}
+
+	result.HasChanges = len(changes) > 0
+	result.Changes = changes
+	if len(sorted) > 0 {
+		result.ChangePercent = float64(len(changes)) / float64(len(sorted)) * 100
+	}
+	result.Diff = buf.String()
+
+	return result, nil
+}
+
+func decodePages(content []byte) (map[string]string, error) {
+	pages := map[string]string{}
+	if len(content) == 0 {
+		return pages, nil
+	}
+	if err := json.Unmarshal(content, &pages); err != nil {
+		return nil, fmt.Errorf("failed to decode pages: %w", err)
+	}
+	return pages, nil
+}
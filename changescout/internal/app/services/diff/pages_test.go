@@ -0,0 +1,42 @@
+package diff
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (s *DiffTestSuite) TestComparePages() {
+	previous := []byte(`{"https://a.com/":"home","https://a.com/old":"old","https://a.com/same":"same"}`)
+	current := []byte(`{"https://a.com/":"home v2","https://a.com/new":"new","https://a.com/same":"same"}`)
+
+	result, err := s.service.ComparePages(previous, current)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), result.HasChanges)
+	assert.Equal(s.T(), []Change{
+		{Type: Modified, Content: "home v2", Path: "https://a.com/"},
+		{Type: Added, Content: "new", Path: "https://a.com/new"},
+		{Type: Removed, Content: "old", Path: "https://a.com/old"},
+	}, result.Changes)
+	assert.Equal(s.T(), 75.0, result.ChangePercent)
+	assert.Contains(s.T(), result.Diff, "added https://a.com/new")
+}
+
+func (s *DiffTestSuite) TestComparePagesNoChanges() {
+	pages := []byte(`{"https://a.com/":"home"}`)
+
+	result, err := s.service.ComparePages(pages, pages)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), result.HasChanges)
+	assert.Empty(s.T(), result.Changes)
+}
+
+func (s *DiffTestSuite) TestComparePagesFirstCheck() {
+	result, err := s.service.ComparePages(nil, []byte(`{"https://a.com/":"home"}`))
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), result.HasChanges)
+	assert.Equal(s.T(), Added, result.Changes[0].Type)
+}
+
+func (s *DiffTestSuite) TestComparePagesInvalidContent() {
+	_, err := s.service.ComparePages([]byte("not json"), []byte(`{}`))
+	assert.Error(s.T(), err)
+}
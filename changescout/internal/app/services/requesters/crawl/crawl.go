@@ -0,0 +1,190 @@
+package crawl
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	DefaultMaxDepth = 1
+	DefaultMaxPages = 20
+	// MaxPages is the upper limit of crawled pages regardless of the website settings.
+	MaxPages = 500
+)
+
+// skippedExtensions are the extensions of the links to documents, media and assets which are not crawled.
+var skippedExtensions = map[string]struct{}{
+	".pdf": {}, ".zip": {}, ".gz": {}, ".tar": {}, ".rar": {}, ".7z": {}, ".exe": {}, ".dmg": {},
+	".doc": {}, ".docx": {}, ".xls": {}, ".xlsx": {}, ".ppt": {}, ".pptx": {}, ".odt": {}, ".ods": {},
+	".jpg": {}, ".jpeg": {}, ".png": {}, ".gif": {}, ".svg": {}, ".webp": {}, ".ico": {}, ".bmp": {}, ".avif": {},
+	".mp3": {}, ".mp4": {}, ".wav": {}, ".avi": {}, ".mov": {}, ".webm": {},
+	".css": {}, ".js": {}, ".woff": {}, ".woff2": {}, ".ttf": {}, ".eot": {},
+}
+
+type Fetcher interface {
+	Request(domain.Website) ([]byte, error)
+}
+
+type CrawlService struct {
+	fetcher Fetcher
+}
+
+func New(fetcher Fetcher) *CrawlService {
+	return &CrawlService{
+		fetcher: fetcher,
+	}
+}
+
+type target struct {
+	url   *url.URL
+	depth int
+}
+
+// Request crawls the website and returns a JSON encoded domain.PageSnapshot of raw page bodies.
+// Only the failure of the start page fails the request, the other failed pages are kept as domain.PageError,
+// so the check can carry them over from the previous snapshot. The pages which are not text are skipped.
+func (s CrawlService) Request(site domain.Website) ([]byte, error) {
+	conf := site.Setting.Crawl
+
+	root, err := url.Parse(site.URL)
+	if err != nil {
+		return nil, err
+	}
+	root.Fragment = ""
+
+	include, err := CompilePatterns(conf.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := CompilePatterns(conf.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	maxDepth := DefaultMaxDepth
+	if conf.MaxDepth != nil {
+		maxDepth = *conf.MaxDepth
+	}
+	maxPages := conf.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+	maxPages = min(maxPages, MaxPages)
+
+	pages := domain.PageSnapshot{}
+	visited := map[string]struct{}{root.String(): {}}
+	queue := []target{{url: root}}
+
+	for len(queue) > 0 && len(pages) < maxPages {
+		current := queue[0]
+		queue = queue[1:]
+
+		page := site
+		page.URL = current.url.String()
+
+		body, err := s.fetcher.Request(page)
+		if err != nil {
+			if current.depth == 0 {
+				return nil, err
+			}
+			pages[page.URL] = domain.PageError(err)
+			continue
+		}
+		if current.depth > 0 && !isText(body) {
+			continue
+		}
+		pages[page.URL] = string(body)
+
+		if current.depth >= maxDepth {
+			continue
+		}
+
+		for _, link := range extractLinks(current.url, body) {
+			key := link.String()
+			if _, ok := visited[key]; ok {
+				continue
+			}
+			visited[key] = struct{}{}
+
+			if !sameOrigin(root, link) || !crawlable(link) || !allowed(key, include, exclude) {
+				continue
+			}
+			queue = append(queue, target{url: link, depth: current.depth + 1})
+		}
+	}
+
+	return json.Marshal(pages)
+}
+
+// CompilePatterns compiles the include or exclude URL patterns.
+func CompilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	result := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, re)
+	}
+	return result, nil
+}
+
+func extractLinks(base *url.URL, body []byte) []*url.URL {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+
+	var links []*url.URL
+	doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		link, err := base.Parse(href)
+		if err != nil {
+			return
+		}
+		link.Fragment = ""
+		links = append(links, link)
+	})
+
+	return links
+}
+
+// crawlable reports whether the link may be a page, the links to documents, media and assets are not.
+func crawlable(link *url.URL) bool {
+	_, skipped := skippedExtensions[strings.ToLower(path.Ext(link.Path))]
+	return !skipped
+}
+
+// isText reports whether the fetched content is a text page, e.g. not a PDF or an image behind a plain link.
+func isText(body []byte) bool {
+	contentType := http.DetectContentType(body)
+	return strings.HasPrefix(contentType, "text/") || strings.HasPrefix(contentType, "application/json")
+}
+
+func sameOrigin(root, link *url.URL) bool {
+	return link.Scheme == root.Scheme && link.Host == root.Host
+}
+
+func allowed(link string, include, exclude []*regexp.Regexp) bool {
+	for _, re := range exclude {
+		if re.MatchString(link) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, re := range include {
+		if re.MatchString(link) {
+			return true
+		}
+	}
+	return false
+}
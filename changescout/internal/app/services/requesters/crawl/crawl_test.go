@@ -0,0 +1,139 @@
+package crawl
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/utils/transform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFetcher map[string]string
+
+func (f fakeFetcher) Request(site domain.Website) ([]byte, error) {
+	body, ok := f[site.URL]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return []byte(body), nil
+}
+
+var site = fakeFetcher{
+	"https://docs.example.com/": `<a href="/intro">Intro</a>
+		<a href="guide#top">Guide</a>
+		<a href="https://other.example.com/">Other</a>
+		<a href="/missing">Missing</a>
+		<a href="mailto:docs@example.com">Mail</a>
+		<a href="/manual.PDF">Manual</a>
+		<a href="/download">Download</a>`,
+	"https://docs.example.com/manual.PDF": `Manual`,
+	"https://docs.example.com/download":   "%PDF-1.7\n\x00\x01binary",
+	"https://docs.example.com/intro":      `<a href="/">Home</a><a href="/intro/deep">Deep</a>`,
+	"https://docs.example.com/guide":      `Guide`,
+	"https://docs.example.com/intro/deep": `Deep`,
+}
+
+func TestCrawlService_Request(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     domain.CrawlOption
+		expected []string
+	}{
+		{
+			name: "Default depth",
+			expected: []string{
+				"https://docs.example.com/",
+				"https://docs.example.com/intro",
+				"https://docs.example.com/guide",
+				"https://docs.example.com/missing",
+			},
+		},
+		{
+			name: "Start page only",
+			conf: domain.CrawlOption{MaxDepth: transform.ToPtr(0)},
+			expected: []string{
+				"https://docs.example.com/",
+			},
+		},
+		{
+			name: "Deeper crawl",
+			conf: domain.CrawlOption{MaxDepth: transform.ToPtr(2)},
+			expected: []string{
+				"https://docs.example.com/",
+				"https://docs.example.com/intro",
+				"https://docs.example.com/guide",
+				"https://docs.example.com/missing",
+				"https://docs.example.com/intro/deep",
+			},
+		},
+		{
+			name: "Page limit",
+			conf: domain.CrawlOption{MaxDepth: transform.ToPtr(2), MaxPages: 2},
+			expected: []string{
+				"https://docs.example.com/",
+				"https://docs.example.com/intro",
+			},
+		},
+		{
+			name: "Include and exclude patterns",
+			conf: domain.CrawlOption{
+				MaxDepth: transform.ToPtr(2),
+				Include:  []string{"/intro"},
+				Exclude:  []string{"/deep$"},
+			},
+			expected: []string{
+				"https://docs.example.com/",
+				"https://docs.example.com/intro",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body, err := New(site).Request(domain.Website{
+				URL:     "https://docs.example.com/",
+				Setting: domain.Setting{Crawl: test.conf},
+			})
+			require.NoError(t, err)
+
+			var pages domain.PageSnapshot
+			require.NoError(t, json.Unmarshal(body, &pages))
+
+			var urls []string
+			for u := range pages {
+				urls = append(urls, u)
+			}
+			assert.ElementsMatch(t, test.expected, urls)
+			assert.Equal(t, site["https://docs.example.com/"], pages["https://docs.example.com/"])
+		})
+	}
+}
+
+func TestCrawlService_RequestPageError(t *testing.T) {
+	body, err := New(site).Request(domain.Website{URL: "https://docs.example.com/"})
+	require.NoError(t, err)
+
+	var pages domain.PageSnapshot
+	require.NoError(t, json.Unmarshal(body, &pages))
+
+	assert.True(t, domain.IsPageError(pages["https://docs.example.com/missing"]))
+	assert.False(t, domain.IsPageError(pages["https://docs.example.com/intro"]))
+}
+
+func TestCrawlService_RequestStartPageError(t *testing.T) {
+	_, err := New(site).Request(domain.Website{URL: "https://docs.example.com/unknown"})
+	assert.Error(t, err)
+}
+
+func TestCrawlService_RequestInvalidPattern(t *testing.T) {
+	_, err := New(site).Request(domain.Website{
+		URL: "https://docs.example.com/",
+		Setting: domain.Setting{
+			Crawl: domain.CrawlOption{Include: []string{"("}},
+		},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,205 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/utils/transform"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// maxStdout caps the content of the command, a larger one fails the request.
+	maxStdout = 10 << 20
+	// maxStderr caps the kept stderr, the rest is dropped.
+	maxStderr = 64 << 10
+	// waitDelay is how long the output of the command is read after it exits or is killed,
+	// so a background process holding the output can't block the check.
+	waitDelay = 5 * time.Second
+)
+
+var (
+	ErrCommandNotAllowed = errors.New("command is not allowed")
+	ErrEnvNotAllowed     = errors.New("environment variable is not allowed")
+	ErrOutputTooLarge    = errors.New("command output is too large")
+)
+
+type ExecService struct {
+	// commands maps the allow-listed commands to their absolute paths resolved at startup
+	commands       map[string]string
+	allowedEnv     []string
+	defaultTimeout time.Duration
+	maxTimeout     time.Duration
+}
+
+func New(opts ...func(*options) *options) *ExecService {
+	opt := transform.Pipe[*options](
+		&options{
+			defaultTimeout: time.Second * 30,
+			maxTimeout:     time.Minute * 5,
+		},
+		opts...,
+	)
+
+	return &ExecService{
+		commands:       resolveCommands(opt.allowedCommands),
+		allowedEnv:     opt.allowedEnv,
+		defaultTimeout: opt.defaultTimeout,
+		maxTimeout:     opt.maxTimeout,
+	}
+}
+
+// resolveCommands resolves the commands to absolute paths, so neither the PATH nor the working directory
+// of a website can swap the executable. The commands which can't be resolved are not allowed.
+func resolveCommands(commands []string) map[string]string {
+	resolved := make(map[string]string, len(commands))
+	for _, command := range commands {
+		path, err := exec.LookPath(command)
+		if err != nil {
+			continue
+		}
+		if path, err = filepath.Abs(path); err != nil {
+			continue
+		}
+		resolved[command] = path
+		resolved[path] = path
+	}
+	return resolved
+}
+
+// Request runs the configured command and returns its stdout.
+// A non-zero exit code is reported as *domain.CommandError carrying the captured stderr.
+func (s ExecService) Request(site domain.Website) ([]byte, error) {
+	output, err := s.Command(site)
+	if err != nil {
+		return nil, err
+	}
+	return output.Stdout, nil
+}
+
+// Command runs the configured command and returns its stdout and stderr.
+// The command gets only the PATH of the server and the allow-listed environment variables.
+func (s ExecService) Command(site domain.Website) (domain.CommandOutput, error) {
+	conf := site.Setting.Exec
+	path, err := s.command(conf)
+	if err != nil {
+		return domain.CommandOutput{}, err
+	}
+
+	env, err := s.environ(conf.Env)
+	if err != nil {
+		return domain.CommandOutput{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout(conf))
+	defer cancel()
+
+	stdout := &limitedBuffer{max: maxStdout}
+	stderr := &limitedBuffer{max: maxStderr}
+	cmd := exec.CommandContext(ctx, path, conf.Args...)
+	cmd.Dir = conf.Dir
+	cmd.Env = env
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = waitDelay
+
+	err = cmd.Run()
+	if err == nil && stdout.exceeded {
+		err = fmt.Errorf("%w: more than %d MB", ErrOutputTooLarge, maxStdout>>20)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return domain.CommandOutput{}, &domain.CommandError{
+			ExitCode: exitCode(err),
+			Stderr:   string(stderr.Bytes()),
+			Err:      err,
+		}
+	}
+
+	return domain.CommandOutput{Stdout: stdout.Bytes(), Stderr: string(stderr.Bytes())}, nil
+}
+
+// timeout returns the timeout of the website, capped by the maximum of the server.
+func (s ExecService) timeout(conf domain.ExecOption) time.Duration {
+	timeout := s.defaultTimeout
+	if conf.Timeout != nil {
+		timeout = time.Second * time.Duration(*conf.Timeout)
+	}
+	if s.maxTimeout > 0 && timeout > s.maxTimeout {
+		timeout = s.maxTimeout
+	}
+	return timeout
+}
+
+// command returns the absolute path of the allow-listed command
+func (s ExecService) command(conf domain.ExecOption) (string, error) {
+	// A relative path would be resolved against the working directory chosen by the website
+	if conf.Dir != "" && strings.ContainsRune(conf.Command, filepath.Separator) && !filepath.IsAbs(conf.Command) {
+		return "", fmt.Errorf("%w: %q is relative to the working directory", ErrCommandNotAllowed, conf.Command)
+	}
+
+	path, ok := s.commands[conf.Command]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrCommandNotAllowed, conf.Command)
+	}
+	return path, nil
+}
+
+// environ builds the environment of the command from the PATH of the server, the allow-listed variables
+// of the server and the variables of the website. The website can set the allow-listed variables only.
+func (s ExecService) environ(env []string) ([]string, error) {
+	result := []string{"PATH=" + os.Getenv("PATH")}
+	for _, name := range s.allowedEnv {
+		if value, ok := os.LookupEnv(name); ok {
+			result = append(result, name+"="+value)
+		}
+	}
+
+	for _, variable := range env {
+		name, _, _ := strings.Cut(variable, "=")
+		if !slices.Contains(s.allowedEnv, name) {
+			return nil, fmt.Errorf("%w: %q", ErrEnvNotAllowed, name)
+		}
+		result = append(result, variable)
+	}
+
+	return result, nil
+}
+
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// limitedBuffer keeps up to max bytes and drops the rest, the command isn't blocked on a full pipe.
+// The buffer is not embedded, its ReadFrom would be used by io.Copy instead of Write.
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	max      int
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.buf.Len(); len(p) > room {
+		b.exceeded = true
+		b.buf.Write(p[:max(room, 0)])
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
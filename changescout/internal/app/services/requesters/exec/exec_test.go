@@ -0,0 +1,194 @@
+package exec
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/utils/transform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecService_Request(t *testing.T) {
+	service := New(WithAllowedCommands([]string{"sh"}), WithAllowedEnv([]string{"GREETING"}))
+
+	tests := []struct {
+		name     string
+		conf     domain.ExecOption
+		expected []byte
+		exitCode int
+		stderr   string
+		wantErr  error
+	}{
+		{
+			name: "Stdout is returned",
+			conf: domain.ExecOption{
+				Command: "sh",
+				Args:    []string{"-c", "echo $GREETING; pwd"},
+				Env:     []string{"GREETING=hello"},
+				Dir:     "/",
+			},
+			expected: []byte("hello\n/\n"),
+		},
+		{
+			name: "Non-zero exit code",
+			conf: domain.ExecOption{
+				Command: "sh",
+				Args:    []string{"-c", "echo broken >&2; exit 3"},
+			},
+			exitCode: 3,
+			stderr:   "broken\n",
+		},
+		{
+			name: "Timeout",
+			conf: domain.ExecOption{
+				Command: "sh",
+				Args:    []string{"-c", "sleep 5"},
+				Timeout: transform.ToPtr(0),
+			},
+			exitCode: -1,
+		},
+		{
+			name: "Command is not allowed",
+			conf: domain.ExecOption{
+				Command: "ls",
+			},
+			wantErr: ErrCommandNotAllowed,
+		},
+		{
+			name: "Relative command with a working directory",
+			conf: domain.ExecOption{
+				Command: "./sh",
+				Dir:     "/tmp",
+			},
+			wantErr: ErrCommandNotAllowed,
+		},
+		{
+			name: "Environment variable is not allowed",
+			conf: domain.ExecOption{
+				Command: "sh",
+				Args:    []string{"-c", "true"},
+				Env:     []string{"LD_PRELOAD=/tmp/evil.so"},
+			},
+			wantErr: ErrEnvNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body, err := service.Request(domain.Website{
+				Mode:    domain.ModeExec,
+				Setting: domain.Setting{Exec: test.conf},
+			})
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+
+			if test.expected != nil {
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, body)
+				return
+			}
+
+			var cmdErr *domain.CommandError
+			require.ErrorAs(t, err, &cmdErr)
+			assert.Equal(t, test.exitCode, cmdErr.ExitCode)
+			assert.Equal(t, test.stderr, cmdErr.Stderr)
+		})
+	}
+}
+
+func TestExecService_CommandEnvironment(t *testing.T) {
+	t.Setenv("CS_SECRET", "secret")
+	t.Setenv("GREETING", "hello")
+	service := New(WithAllowedCommands([]string{"sh"}), WithAllowedEnv([]string{"GREETING"}))
+
+	output, err := service.Command(domain.Website{
+		Mode: domain.ModeExec,
+		Setting: domain.Setting{Exec: domain.ExecOption{
+			Command: "sh",
+			Args:    []string{"-c", "echo \"$CS_SECRET|$GREETING\"; echo warning >&2"},
+		}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "|hello\n", string(output.Stdout))
+	assert.Equal(t, "warning\n", output.Stderr)
+}
+
+func TestExecService_CommandResolvedPath(t *testing.T) {
+	path, err := exec.LookPath("sh")
+	require.NoError(t, err)
+	path, err = filepath.Abs(path)
+	require.NoError(t, err)
+
+	// The allow-listed command and its resolved path run the same executable
+	service := New(WithAllowedCommands([]string{"sh"}))
+	body, err := service.Request(domain.Website{
+		Mode: domain.ModeExec,
+		Setting: domain.Setting{Exec: domain.ExecOption{
+			Command: path,
+			Args:    []string{"-c", "echo ok"},
+		}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ok\n", string(body))
+}
+
+func TestExecService_CommandOutputLimit(t *testing.T) {
+	service := New(WithAllowedCommands([]string{"sh"}))
+
+	output, err := service.Command(domain.Website{
+		Mode: domain.ModeExec,
+		Setting: domain.Setting{Exec: domain.ExecOption{
+			Command: "sh",
+			Args:    []string{"-c", "head -c 11000000 /dev/zero; head -c 100000 /dev/zero >&2"},
+		}},
+	})
+	assert.ErrorIs(t, err, ErrOutputTooLarge)
+	assert.Empty(t, output.Stdout)
+
+	var cmdErr *domain.CommandError
+	require.ErrorAs(t, err, &cmdErr)
+	assert.Len(t, cmdErr.Stderr, maxStderr)
+}
+
+func TestExecService_CommandMaxTimeout(t *testing.T) {
+	service := New(WithAllowedCommands([]string{"sh"}), WithMaxTimeout(time.Second))
+
+	start := time.Now()
+	_, err := service.Request(domain.Website{
+		Mode: domain.ModeExec,
+		Setting: domain.Setting{Exec: domain.ExecOption{
+			Command: "sh",
+			Args:    []string{"-c", "exec sleep 30"},
+			Timeout: transform.ToPtr(60),
+		}},
+	})
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
+
+func TestExecService_CommandBackgroundProcess(t *testing.T) {
+	service := New(WithAllowedCommands([]string{"sh"}))
+
+	// The background process keeps the output open after the command exits
+	start := time.Now()
+	_, err := service.Request(domain.Website{
+		Mode: domain.ModeExec,
+		Setting: domain.Setting{Exec: domain.ExecOption{
+			Command: "sh",
+			Args:    []string{"-c", "sleep 30 & echo ok"},
+		}},
+	})
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), waitDelay+5*time.Second)
+}
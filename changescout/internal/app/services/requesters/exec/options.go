@@ -0,0 +1,45 @@
+package exec
+
+import "time"
+
+type options struct {
+	allowedCommands []string
+	allowedEnv      []string
+	defaultTimeout  time.Duration
+	maxTimeout      time.Duration
+}
+
+type optionFunc func(*options) *options
+
+// WithAllowedCommands sets the commands which websites are permitted to run.
+func WithAllowedCommands(commands []string) optionFunc {
+	return func(o *options) *options {
+		o.allowedCommands = append(o.allowedCommands, commands...)
+		return o
+	}
+}
+
+// WithAllowedEnv sets the names of the environment variables which are passed from the server
+// and which websites are permitted to set.
+func WithAllowedEnv(names []string) optionFunc {
+	return func(o *options) *options {
+		o.allowedEnv = append(o.allowedEnv, names...)
+		return o
+	}
+}
+
+// WithDefaultTimeout sets the timeout for websites without their own timeout.
+func WithDefaultTimeout(timeout time.Duration) optionFunc {
+	return func(o *options) *options {
+		o.defaultTimeout = timeout
+		return o
+	}
+}
+
+// WithMaxTimeout caps the timeout of the websites, 0 doesn't cap it.
+func WithMaxTimeout(timeout time.Duration) optionFunc {
+	return func(o *options) *options {
+		o.maxTimeout = timeout
+		return o
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/gelleson/changescout/changescout/internal/domain"
 	"io"
 	"net/http"
+	"strings"
 )
 
 //go:generate mockery --name Doer
@@ -13,28 +14,45 @@ type Doer interface {
 }
 
 type HttpService struct {
-	doer Doer
+	doer    Doer
+	clients *clientPool
 }
 
+// New creates a requester which uses doer for websites without TLS settings
+// and builds a dedicated client for every website with TLS settings.
 func New(doer Doer) *HttpService {
 	return &HttpService{
-		doer: doer,
+		doer:    doer,
+		clients: newClientPool(),
 	}
 }
 
 func (h HttpService) Request(site domain.Website) ([]byte, error) {
-	req, err := http.NewRequest(site.Setting.Method, site.URL, nil)
+	var reqBody io.Reader
+	if site.Setting.Body != "" {
+		reqBody = strings.NewReader(site.Setting.Body)
+	}
+
+	req, err := http.NewRequest(site.Setting.Method, site.URL, reqBody)
 	if err != nil {
 		return nil, err
 	}
-	req.Header = site.Setting.Headers
+	if site.Setting.Headers != nil {
+		req.Header = site.Setting.Headers.Clone()
+	}
 	req.Header.Set("User-Agent", site.Setting.UserAgent)
 	req.Header.Set("Referer", site.Setting.Referer)
 
-	resp, err := h.doer.Do(req)
+	doer, err := h.doerFor(site)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := doer.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
 		return nil, errors.New("bad status code")
@@ -47,3 +65,10 @@ func (h HttpService) Request(site domain.Website) ([]byte, error) {
 
 	return body, nil
 }
+
+func (h HttpService) doerFor(site domain.Website) (Doer, error) {
+	if site.Setting.TLS.IsZero() {
+		return h.doer, nil
+	}
+	return h.clients.get(site.ID, site.Setting.TLS)
+}
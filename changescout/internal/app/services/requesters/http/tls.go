@@ -0,0 +1,119 @@
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/google/uuid"
+	"net/http"
+	"sync"
+)
+
+var (
+	ErrInvalidCACertificate = errors.New("invalid CA certificate")
+	ErrInvalidClientKeyPair = errors.New("invalid client certificate or key")
+	ErrInvalidTLSVersion    = errors.New("invalid TLS version")
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// BuildTLSConfig converts the website TLS settings into a tls.Config.
+func BuildTLSConfig(opt domain.TLSOption) (*tls.Config, error) {
+	conf := &tls.Config{
+		InsecureSkipVerify: opt.InsecureSkipVerify,
+	}
+
+	if opt.MinVersion != "" {
+		version, ok := tlsVersions[opt.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTLSVersion, opt.MinVersion)
+		}
+		conf.MinVersion = version
+	}
+
+	if len(opt.CACertificates) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, pem := range opt.CACertificates {
+			if !pool.AppendCertsFromPEM([]byte(pem)) {
+				return nil, ErrInvalidCACertificate
+			}
+		}
+		conf.RootCAs = pool
+	}
+
+	if opt.ClientCertificate != "" || opt.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(opt.ClientCertificate), []byte(opt.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidClientKeyPair, err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+
+	return conf, nil
+}
+
+// clientPool keeps a dedicated http.Client for every website with TLS settings, so connections are reused
+// between its checks. The client of a website is replaced when its settings change, so the pool holds
+// at most one client per website.
+type clientPool struct {
+	mu      sync.Mutex
+	clients map[uuid.UUID]pooledClient
+}
+
+type pooledClient struct {
+	// hash identifies the TLS settings the client is built from
+	hash   string
+	client *http.Client
+}
+
+func newClientPool() *clientPool {
+	return &clientPool{
+		clients: make(map[uuid.UUID]pooledClient),
+	}
+}
+
+func (p *clientPool) get(websiteID uuid.UUID, opt domain.TLSOption) (Doer, error) {
+	raw, err := json.Marshal(opt)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(raw)
+	hash := hex.EncodeToString(sum[:])
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	previous, ok := p.clients[websiteID]
+	if ok && previous.hash == hash {
+		return previous.client, nil
+	}
+
+	conf, err := BuildTLSConfig(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = conf
+
+	client := &http.Client{Transport: transport}
+	p.clients[websiteID] = pooledClient{hash: hash, client: client}
+	if ok {
+		previous.client.CloseIdleConnections()
+	}
+
+	return client, nil
+}
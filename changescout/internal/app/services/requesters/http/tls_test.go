@@ -0,0 +1,141 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "changescout"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	return string(certPEM), string(keyPEM)
+}
+
+func TestBuildTLSConfig(t *testing.T) {
+	cert, key := generateCertificate(t)
+
+	tests := []struct {
+		name    string
+		opt     domain.TLSOption
+		wantErr error
+		check   func(t *testing.T, conf *tls.Config)
+	}{
+		{
+			name: "Insecure mode",
+			opt:  domain.TLSOption{InsecureSkipVerify: true},
+			check: func(t *testing.T, conf *tls.Config) {
+				assert.True(t, conf.InsecureSkipVerify)
+			},
+		},
+		{
+			name: "Minimum version",
+			opt:  domain.TLSOption{MinVersion: "1.3"},
+			check: func(t *testing.T, conf *tls.Config) {
+				assert.Equal(t, uint16(tls.VersionTLS13), conf.MinVersion)
+			},
+		},
+		{
+			name:    "Invalid minimum version",
+			opt:     domain.TLSOption{MinVersion: "2.0"},
+			wantErr: ErrInvalidTLSVersion,
+		},
+		{
+			name: "Extra CA certificate",
+			opt:  domain.TLSOption{CACertificates: []string{cert}},
+			check: func(t *testing.T, conf *tls.Config) {
+				assert.NotNil(t, conf.RootCAs)
+			},
+		},
+		{
+			name:    "Invalid CA certificate",
+			opt:     domain.TLSOption{CACertificates: []string{"not a pem"}},
+			wantErr: ErrInvalidCACertificate,
+		},
+		{
+			name: "Client certificate",
+			opt:  domain.TLSOption{ClientCertificate: cert, ClientKey: key},
+			check: func(t *testing.T, conf *tls.Config) {
+				assert.Len(t, conf.Certificates, 1)
+			},
+		},
+		{
+			name:    "Client certificate without key",
+			opt:     domain.TLSOption{ClientCertificate: cert},
+			wantErr: ErrInvalidClientKeyPair,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf, err := BuildTLSConfig(test.opt)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			test.check(t, conf)
+		})
+	}
+}
+
+func TestClientPool_Get(t *testing.T) {
+	pool := newClientPool()
+	websiteID := uuid.New()
+
+	first, err := pool.get(websiteID, domain.TLSOption{InsecureSkipVerify: true})
+	require.NoError(t, err)
+
+	second, err := pool.get(websiteID, domain.TLSOption{InsecureSkipVerify: true})
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	other, err := pool.get(uuid.New(), domain.TLSOption{InsecureSkipVerify: true})
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+}
+
+func TestClientPool_GetReplacesChangedSettings(t *testing.T) {
+	pool := newClientPool()
+	websiteID := uuid.New()
+
+	first, err := pool.get(websiteID, domain.TLSOption{InsecureSkipVerify: true})
+	require.NoError(t, err)
+
+	updated, err := pool.get(websiteID, domain.TLSOption{MinVersion: "1.2"})
+	require.NoError(t, err)
+	assert.NotSame(t, first, updated)
+	assert.Len(t, pool.clients, 1)
+}
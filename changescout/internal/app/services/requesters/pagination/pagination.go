@@ -0,0 +1,236 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/oliveagle/jsonpath"
+	"net/url"
+	"strconv"
+)
+
+const (
+	DefaultMaxPages = 10
+	// MaxPages is the upper limit of fetched pages regardless of the website settings.
+	MaxPages = 100
+)
+
+var ErrAmbiguousPagination = errors.New("only one of next selector, next json path or page param can be set")
+
+type Fetcher interface {
+	Request(domain.Website) ([]byte, error)
+}
+
+// PaginationService wraps a requester and follows the next pages of websites with pagination settings.
+type PaginationService struct {
+	fetcher Fetcher
+}
+
+func New(fetcher Fetcher) *PaginationService {
+	return &PaginationService{
+		fetcher: fetcher,
+	}
+}
+
+// Request fetches every page and merges them into a single document.
+// JSON pages are merged into a JSON array, other pages are joined by a newline. A single page is returned as is.
+func (s PaginationService) Request(site domain.Website) ([]byte, error) {
+	conf := site.Setting.Pagination
+	if conf.IsZero() {
+		return s.fetcher.Request(site)
+	}
+	if err := Validate(conf); err != nil {
+		return nil, err
+	}
+
+	maxPages := conf.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+	maxPages = min(maxPages, MaxPages)
+
+	startPage := 1
+	if conf.StartPage != nil {
+		startPage = *conf.StartPage
+	}
+
+	next := site.URL
+	if conf.PageParam != "" {
+		var err error
+		if next, err = withQuery(site.URL, conf.PageParam, strconv.Itoa(startPage)); err != nil {
+			return nil, err
+		}
+	}
+
+	var pages [][]byte
+	visited := map[string]struct{}{}
+	items := map[string]struct{}{}
+	for i := 0; i < maxPages && next != ""; i++ {
+		if _, ok := visited[next]; ok {
+			break
+		}
+		visited[next] = struct{}{}
+
+		page := site
+		page.URL = next
+
+		body, err := s.fetcher.Request(page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch page %d: %w", i+1, err)
+		}
+		if i > 0 && (isEmptyPage(body) || bytes.Equal(body, pages[len(pages)-1])) {
+			break
+		}
+		if conf.ItemSelector != "" {
+			fresh, err := newItems(conf.ItemSelector, body, items)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse page %d: %w", i+1, err)
+			}
+			if i > 0 && !fresh {
+				break
+			}
+		}
+		pages = append(pages, body)
+
+		next, err = nextURL(conf, page.URL, body, startPage+i+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return merge(pages), nil
+}
+
+// Validate checks the pagination settings.
+func Validate(conf domain.PaginationOption) error {
+	set := 0
+	for _, value := range []string{conf.NextSelector, conf.NextJSONPath, conf.PageParam} {
+		if value != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return ErrAmbiguousPagination
+	}
+	if conf.MaxPages < 0 || conf.MaxPages > MaxPages {
+		return fmt.Errorf("max pages must be between 0 and %d", MaxPages)
+	}
+	if conf.NextJSONPath != "" {
+		if _, err := jsonpath.Compile(conf.NextJSONPath); err != nil {
+			return fmt.Errorf("invalid next json path: %w", err)
+		}
+	}
+	return nil
+}
+
+func nextURL(conf domain.PaginationOption, current string, body []byte, pageNumber int) (string, error) {
+	switch {
+	case conf.NextSelector != "":
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+		if err != nil {
+			return "", err
+		}
+		href, ok := doc.Find(conf.NextSelector).First().Attr("href")
+		if !ok || href == "" {
+			return "", nil
+		}
+		return resolve(current, href)
+	case conf.NextJSONPath != "":
+		var data interface{}
+		if err := json.Unmarshal(body, &data); err != nil {
+			return "", fmt.Errorf("failed to decode page: %w", err)
+		}
+		value, err := jsonpath.JsonPathLookup(data, conf.NextJSONPath)
+		if err != nil || value == nil {
+			return "", nil
+		}
+		cursor := fmt.Sprint(value)
+		if cursor == "" {
+			return "", nil
+		}
+		if conf.CursorParam != "" {
+			return withQuery(current, conf.CursorParam, cursor)
+		}
+		return resolve(current, cursor)
+	default:
+		return withQuery(current, conf.PageParam, strconv.Itoa(pageNumber))
+	}
+}
+
+func resolve(base, ref string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	refURL, err := baseURL.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return refURL.String(), nil
+}
+
+func withQuery(rawURL, key, value string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// newItems reports whether the page has items matched by the selector which are not in seen, and adds them to seen.
+func newItems(selector string, body []byte, seen map[string]struct{}) (bool, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	fresh := false
+	doc.Find(selector).Each(func(_ int, item *goquery.Selection) {
+		key, err := goquery.OuterHtml(item)
+		if err != nil {
+			return
+		}
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			fresh = true
+		}
+	})
+	return fresh, nil
+}
+
+func isEmptyPage(body []byte) bool {
+	switch string(bytes.TrimSpace(body)) {
+	case "", "[]", "{}", "null":
+		return true
+	}
+	return false
+}
+
+// merge joins the HTML and text pages with new lines. The JSON pages are always wrapped in an array,
+// even a single one, so the settings selecting from the array keep matching when the listing grows.
+func merge(pages [][]byte) []byte {
+	allJSON := true
+	for _, page := range pages {
+		if !json.Valid(page) {
+			allJSON = false
+			break
+		}
+	}
+
+	if !allJSON {
+		return bytes.Join(pages, []byte{'\n'})
+	}
+
+	raw := make([]json.RawMessage, 0, len(pages))
+	for _, page := range pages {
+		raw = append(raw, page)
+	}
+	merged, _ := json.Marshal(raw)
+	return merged
+}
@@ -0,0 +1,152 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/utils/transform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFetcher map[string]string
+
+func (f fakeFetcher) Request(site domain.Website) ([]byte, error) {
+	body, ok := f[site.URL]
+	if !ok {
+		return nil, errors.New("not found: " + site.URL)
+	}
+	return []byte(body), nil
+}
+
+func TestPaginationService_Request(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		conf     domain.PaginationOption
+		fetcher  fakeFetcher
+		expected string
+	}{
+		{
+			name: "Without pagination",
+			url:  "https://example.com/list",
+			fetcher: fakeFetcher{
+				"https://example.com/list": "page 1",
+			},
+			expected: "page 1",
+		},
+		{
+			name: "Next link selector",
+			url:  "https://example.com/list",
+			conf: domain.PaginationOption{NextSelector: "a.next"},
+			fetcher: fakeFetcher{
+				"https://example.com/list":        `<li>1</li><a class="next" href="/list?page=2">Next</a>`,
+				"https://example.com/list?page=2": `<li>2</li>`,
+			},
+			expected: "<li>1</li><a class=\"next\" href=\"/list?page=2\">Next</a>\n<li>2</li>",
+		},
+		{
+			name: "Next URL by JSONPath",
+			url:  "https://api.example.com/items",
+			conf: domain.PaginationOption{NextJSONPath: "$.next"},
+			fetcher: fakeFetcher{
+				"https://api.example.com/items":         `{"items":[1],"next":"/items?after=1"}`,
+				"https://api.example.com/items?after=1": `{"items":[2],"next":null}`,
+			},
+			expected: `[{"items":[1],"next":"/items?after=1"},{"items":[2],"next":null}]`,
+		},
+		{
+			name: "Single JSON page",
+			url:  "https://api.example.com/items",
+			conf: domain.PaginationOption{NextJSONPath: "$.next"},
+			fetcher: fakeFetcher{
+				"https://api.example.com/items": `{"items":[1],"next":null}`,
+			},
+			expected: `[{"items":[1],"next":null}]`,
+		},
+		{
+			name: "Cursor by JSONPath",
+			url:  "https://api.example.com/items?limit=1",
+			conf: domain.PaginationOption{NextJSONPath: "$.cursor", CursorParam: "cursor"},
+			fetcher: fakeFetcher{
+				"https://api.example.com/items?limit=1":            `{"items":[1],"cursor":"abc"}`,
+				"https://api.example.com/items?cursor=abc&limit=1": `{"items":[2],"cursor":""}`,
+			},
+			expected: `[{"items":[1],"cursor":"abc"},{"items":[2],"cursor":""}]`,
+		},
+		{
+			name: "Page parameter until empty page",
+			url:  "https://api.example.com/items",
+			conf: domain.PaginationOption{PageParam: "page", StartPage: transform.ToPtr(0)},
+			fetcher: fakeFetcher{
+				"https://api.example.com/items?page=0": `[1]`,
+				"https://api.example.com/items?page=1": `[2]`,
+				"https://api.example.com/items?page=2": `[]`,
+			},
+			expected: `[[1],[2]]`,
+		},
+		{
+			name: "Page parameter with max pages",
+			url:  "https://api.example.com/items",
+			conf: domain.PaginationOption{PageParam: "page", MaxPages: 1},
+			fetcher: fakeFetcher{
+				"https://api.example.com/items?page=1": `[1]`,
+			},
+			expected: `[[1]]`,
+		},
+		{
+			name: "Page parameter until a page without new items",
+			url:  "https://example.com/products",
+			conf: domain.PaginationOption{PageParam: "page", ItemSelector: ".product"},
+			fetcher: fakeFetcher{
+				"https://example.com/products?page=1": `<ul><li class="product">a</li><li class="product">b</li></ul>`,
+				"https://example.com/products?page=2": `<ul><li class="product">c</li></ul><p>Page 2</p>`,
+				"https://example.com/products?page=3": `<p>No results for page 3</p>`,
+			},
+			expected: "<ul><li class=\"product\">a</li><li class=\"product\">b</li></ul>\n<ul><li class=\"product\">c</li></ul><p>Page 2</p>",
+		},
+		{
+			name: "Page parameter until a repeated last page",
+			url:  "https://example.com/products",
+			conf: domain.PaginationOption{PageParam: "page", ItemSelector: ".product"},
+			fetcher: fakeFetcher{
+				"https://example.com/products?page=1": `<li class="product">a</li><span>page 1</span>`,
+				"https://example.com/products?page=2": `<li class="product">a</li><span>page 2</span>`,
+			},
+			expected: `<li class="product">a</li><span>page 1</span>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body, err := New(test.fetcher).Request(domain.Website{
+				URL:     test.url,
+				Setting: domain.Setting{Pagination: test.conf},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(body))
+		})
+	}
+}
+
+func TestPaginationService_RequestPageError(t *testing.T) {
+	fetcher := fakeFetcher{
+		"https://example.com/list": `<a class="next" href="/missing">Next</a>`,
+	}
+
+	_, err := New(fetcher).Request(domain.Website{
+		URL: "https://example.com/list",
+		Setting: domain.Setting{
+			Pagination: domain.PaginationOption{NextSelector: "a.next"},
+		},
+	})
+	assert.ErrorContains(t, err, "failed to fetch page 2")
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(domain.PaginationOption{PageParam: "page"}))
+	assert.ErrorIs(t, Validate(domain.PaginationOption{PageParam: "page", NextSelector: "a"}), ErrAmbiguousPagination)
+	assert.Error(t, Validate(domain.PaginationOption{PageParam: "page", MaxPages: MaxPages + 1}))
+	assert.Error(t, Validate(domain.PaginationOption{NextJSONPath: "next"}))
+}
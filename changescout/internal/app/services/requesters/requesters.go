@@ -1,10 +1,16 @@
 package requesters
 
 import (
+	"fmt"
 	"github.com/gelleson/changescout/changescout/internal/app/services/requesters/browser"
+	"github.com/gelleson/changescout/changescout/internal/app/services/requesters/crawl"
+	execrequesters "github.com/gelleson/changescout/changescout/internal/app/services/requesters/exec"
 	httprequesters "github.com/gelleson/changescout/changescout/internal/app/services/requesters/http"
+	"github.com/gelleson/changescout/changescout/internal/app/services/requesters/pagination"
+	"github.com/gelleson/changescout/changescout/internal/app/services/requesters/workflow"
 	"github.com/gelleson/changescout/changescout/internal/domain"
 	"net/http"
+	"time"
 )
 
 type Provider interface {
@@ -21,15 +27,29 @@ type BrowserOption struct {
 	ManagedInstanceURL *string
 }
 
+type ExecOption struct {
+	Enable          bool
+	AllowedCommands []string
+	// AllowedEnv are the names of the environment variables passed to the commands
+	AllowedEnv []string
+	// MaxTimeout caps the timeout of the commands
+	MaxTimeout time.Duration
+}
+
 type Options struct {
 	Browser BrowserOption
+	Exec    ExecOption
 }
 
 func New(opt Options) *Requester {
+	plain := httprequesters.New(http.DefaultClient)
+
 	return &Requester{
 		providers: Providers{
-			domain.ModePlain: httprequesters.New(http.DefaultClient),
-			domain.ModeRenderer: optional[Provider](
+			domain.ModePlain:    pagination.New(plain),
+			domain.ModeCrawl:    crawl.New(plain),
+			domain.ModeWorkflow: workflow.New(plain),
+			domain.ModeRenderer: pagination.New(optional[Provider](
 				opt.Browser.Enable,
 				func() Provider {
 					return browser.New(
@@ -37,6 +57,17 @@ func New(opt Options) *Requester {
 					)
 				},
 				httprequesters.New(http.DefaultClient),
+			)),
+			domain.ModeExec: optional[Provider](
+				opt.Exec.Enable,
+				func() Provider {
+					return execrequesters.New(
+						execrequesters.WithAllowedCommands(opt.Exec.AllowedCommands),
+						execrequesters.WithAllowedEnv(opt.Exec.AllowedEnv),
+						execrequesters.WithMaxTimeout(opt.Exec.MaxTimeout),
+					)
+				},
+				disabled{mode: domain.ModeExec},
 			),
 		},
 	}
@@ -46,6 +77,31 @@ func (r *Requester) Request(site domain.Website) ([]byte, error) {
 	return r.providers[site.Mode].Request(site)
 }
 
+// commander is a provider reporting the stderr of the successful commands too.
+type commander interface {
+	Command(domain.Website) (domain.CommandOutput, error)
+}
+
+// Command runs the command of the exec mode website and returns its stdout and stderr.
+// The other providers report their content as stdout.
+func (r *Requester) Command(site domain.Website) (domain.CommandOutput, error) {
+	provider, ok := r.providers[site.Mode].(commander)
+	if !ok {
+		body, err := r.Request(site)
+		return domain.CommandOutput{Stdout: body}, err
+	}
+	return provider.Command(site)
+}
+
+// disabled is a provider for modes which are turned off on the server.
+type disabled struct {
+	mode domain.Mode
+}
+
+func (d disabled) Request(domain.Website) ([]byte, error) {
+	return nil, fmt.Errorf("%w: %s", domain.ErrModeDisabled, d.mode)
+}
+
 func optional[T any](enabled bool, builder func() T, fallback T) T {
 	if enabled {
 		return builder()
@@ -0,0 +1,205 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/oliveagle/jsonpath"
+	"net/http"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+var (
+	ErrEmptyWorkflow    = errors.New("workflow has no steps")
+	ErrVariableNotFound = errors.New("variable not found")
+	ErrInvalidVariable  = errors.New("variable must have a name and one of json path or regex")
+)
+
+type Fetcher interface {
+	Request(domain.Website) ([]byte, error)
+}
+
+type WorkflowService struct {
+	fetcher Fetcher
+}
+
+func New(fetcher Fetcher) *WorkflowService {
+	return &WorkflowService{
+		fetcher: fetcher,
+	}
+}
+
+// Request runs the workflow steps in order and returns the response of the last step.
+func (s WorkflowService) Request(site domain.Website) ([]byte, error) {
+	steps := site.Setting.Workflow
+	if len(steps) == 0 {
+		return nil, ErrEmptyWorkflow
+	}
+
+	variables := map[string]string{}
+	var body []byte
+	for i, step := range steps {
+		name := stepName(i, step)
+
+		page, err := buildRequest(site, step, variables)
+		if err != nil {
+			return nil, fmt.Errorf("step %s: %w", name, err)
+		}
+
+		body, err = s.fetcher.Request(page)
+		if err != nil {
+			return nil, fmt.Errorf("step %s: %w", name, err)
+		}
+
+		for _, variable := range step.Variables {
+			value, err := extract(body, variable)
+			if err != nil {
+				return nil, fmt.Errorf("step %s: %w", name, err)
+			}
+			variables[variable.Name] = value
+		}
+	}
+
+	return body, nil
+}
+
+// Validate checks the workflow steps without running them.
+func Validate(steps []domain.WorkflowStep) error {
+	if len(steps) == 0 {
+		return ErrEmptyWorkflow
+	}
+
+	for i, step := range steps {
+		name := stepName(i, step)
+		templates := []string{step.URL, step.Body}
+		for _, header := range step.Headers {
+			templates = append(templates, header.Value)
+		}
+		for _, text := range templates {
+			if _, err := parse(text); err != nil {
+				return fmt.Errorf("step %s: %w", name, err)
+			}
+		}
+
+		for _, variable := range step.Variables {
+			if variable.Name == "" || (variable.JSONPath == "") == (variable.Regex == "") {
+				return fmt.Errorf("step %s: %w", name, ErrInvalidVariable)
+			}
+			if variable.JSONPath != "" {
+				if _, err := jsonpath.Compile(variable.JSONPath); err != nil {
+					return fmt.Errorf("step %s: %w", name, err)
+				}
+			}
+			if variable.Regex != "" {
+				if _, err := regexp.Compile(variable.Regex); err != nil {
+					return fmt.Errorf("step %s: %w", name, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func buildRequest(site domain.Website, step domain.WorkflowStep, variables map[string]string) (domain.Website, error) {
+	page := site
+
+	url := step.URL
+	if url == "" {
+		url = site.URL
+	}
+	url, err := render(url, variables)
+	if err != nil {
+		return domain.Website{}, err
+	}
+	page.URL = url
+
+	page.Setting.Method = step.Method
+	if page.Setting.Method == "" {
+		page.Setting.Method = http.MethodGet
+	}
+
+	page.Setting.Body, err = render(step.Body, variables)
+	if err != nil {
+		return domain.Website{}, err
+	}
+
+	page.Setting.Headers = site.Setting.Headers.Clone()
+	if page.Setting.Headers == nil {
+		page.Setting.Headers = http.Header{}
+	}
+	for _, header := range step.Headers {
+		value, err := render(header.Value, variables)
+		if err != nil {
+			return domain.Website{}, err
+		}
+		page.Setting.Headers.Set(header.Name, value)
+	}
+
+	return page, nil
+}
+
+func extract(body []byte, variable domain.WorkflowVariable) (string, error) {
+	if variable.Regex != "" {
+		re, err := regexp.Compile(variable.Regex)
+		if err != nil {
+			return "", err
+		}
+		match := re.FindSubmatch(body)
+		if match == nil {
+			return "", fmt.Errorf("%w: %s", ErrVariableNotFound, variable.Name)
+		}
+		if len(match) > 1 {
+			return string(match[1]), nil
+		}
+		return string(match[0]), nil
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	value, err := jsonpath.JsonPathLookup(data, variable.JSONPath)
+	if err != nil || value == nil {
+		return "", fmt.Errorf("%w: %s", ErrVariableNotFound, variable.Name)
+	}
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func parse(text string) (*template.Template, error) {
+	return template.New("step").Option("missingkey=error").Parse(text)
+}
+
+func render(text string, variables map[string]string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, variables); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func stepName(i int, step domain.WorkflowStep) string {
+	if step.Name != "" {
+		return fmt.Sprintf("%q", step.Name)
+	}
+	return fmt.Sprintf("#%d", i+1)
+}
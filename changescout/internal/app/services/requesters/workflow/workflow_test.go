@@ -0,0 +1,133 @@
+package workflow
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingFetcher struct {
+	responses map[string]string
+	requests  []domain.Website
+}
+
+func (f *recordingFetcher) Request(site domain.Website) ([]byte, error) {
+	f.requests = append(f.requests, site)
+	body, ok := f.responses[site.URL]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return []byte(body), nil
+}
+
+func TestWorkflowService_Request(t *testing.T) {
+	fetcher := &recordingFetcher{
+		responses: map[string]string{
+			"https://api.example.com/token":    `{"access_token":"secret"}`,
+			"https://api.example.com/search":   `<ul><li data-id="42">Item</li></ul>`,
+			"https://api.example.com/items/42": `{"id":42,"price":10}`,
+		},
+	}
+
+	body, err := New(fetcher).Request(domain.Website{
+		URL: "https://api.example.com/token",
+		Setting: domain.Setting{
+			Workflow: []domain.WorkflowStep{
+				{
+					Name:   "token",
+					Method: http.MethodPost,
+					Body:   `{"grant_type":"client_credentials"}`,
+					Variables: []domain.WorkflowVariable{
+						{Name: "token", JSONPath: "$.access_token"},
+					},
+				},
+				{
+					Name: "search",
+					URL:  "https://api.example.com/search",
+					Headers: []domain.WorkflowHeader{
+						{Name: "Authorization", Value: "Bearer {{ .token }}"},
+					},
+					Variables: []domain.WorkflowVariable{
+						{Name: "id", Regex: `data-id="(\d+)"`},
+					},
+				},
+				{
+					Name: "detail",
+					URL:  "https://api.example.com/items/{{ .id }}",
+				},
+			},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":42,"price":10}`, string(body))
+	require.Len(t, fetcher.requests, 3)
+	assert.Equal(t, http.MethodPost, fetcher.requests[0].Setting.Method)
+	assert.Equal(t, `{"grant_type":"client_credentials"}`, fetcher.requests[0].Setting.Body)
+	assert.Equal(t, "Bearer secret", fetcher.requests[1].Setting.Headers.Get("Authorization"))
+	assert.Equal(t, http.MethodGet, fetcher.requests[2].Setting.Method)
+}
+
+func TestWorkflowService_RequestErrors(t *testing.T) {
+	fetcher := &recordingFetcher{
+		responses: map[string]string{
+			"https://api.example.com/token": `{"other":"value"}`,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		steps   []domain.WorkflowStep
+		wantErr error
+	}{
+		{
+			name:    "Empty workflow",
+			wantErr: ErrEmptyWorkflow,
+		},
+		{
+			name: "Variable not found",
+			steps: []domain.WorkflowStep{
+				{Variables: []domain.WorkflowVariable{{Name: "token", JSONPath: "$.access_token"}}},
+			},
+			wantErr: ErrVariableNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := New(fetcher).Request(domain.Website{
+				URL:     "https://api.example.com/token",
+				Setting: domain.Setting{Workflow: test.steps},
+			})
+			assert.ErrorIs(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestWorkflowService_RequestMissingTemplateVariable(t *testing.T) {
+	_, err := New(&recordingFetcher{}).Request(domain.Website{
+		Setting: domain.Setting{
+			Workflow: []domain.WorkflowStep{{URL: "https://api.example.com/{{ .unknown }}"}},
+		},
+	})
+	assert.ErrorContains(t, err, "step #1")
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate([]domain.WorkflowStep{
+		{Variables: []domain.WorkflowVariable{{Name: "token", Regex: "token=(\\w+)"}}},
+		{URL: "https://example.com/{{ .token }}"},
+	}))
+	assert.ErrorIs(t, Validate(nil), ErrEmptyWorkflow)
+	assert.ErrorIs(t, Validate([]domain.WorkflowStep{
+		{Variables: []domain.WorkflowVariable{{Name: "token", Regex: "a", JSONPath: "$.a"}}},
+	}), ErrInvalidVariable)
+	assert.Error(t, Validate([]domain.WorkflowStep{{URL: "{{ .token"}}))
+	assert.Error(t, Validate([]domain.WorkflowStep{
+		{Variables: []domain.WorkflowVariable{{Name: "token", Regex: "("}}},
+	}))
+}
@@ -3,8 +3,17 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/app/services/diff"
+	"github.com/gelleson/changescout/changescout/internal/app/services/requesters/crawl"
+	httprequesters "github.com/gelleson/changescout/changescout/internal/app/services/requesters/http"
+	"github.com/gelleson/changescout/changescout/internal/app/services/requesters/pagination"
+	"github.com/gelleson/changescout/changescout/internal/app/services/requesters/workflow"
 	"github.com/gelleson/changescout/changescout/internal/domain"
 	"github.com/gelleson/changescout/changescout/internal/infrastructure/database"
+	"github.com/gelleson/changescout/changescout/internal/pkg/numeric"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/gelleson/changescout/changescout/internal/pkg/triggers"
 	"github.com/gelleson/changescout/changescout/internal/utils/transform"
 	"github.com/gelleson/changescout/changescout/pkg/crons"
 	"github.com/gelleson/changescout/changescout/pkg/validators"
@@ -14,6 +23,12 @@ import (
 
 const MaxInterval = time.Hour * 24
 
+var (
+	ErrExecCommandRequired = errors.New("command is required for the exec mode")
+	ErrCrawlLimits         = fmt.Errorf("crawl depth and pages must be between 0 and %d", crawl.MaxPages)
+	ErrInvalidThreshold    = errors.New("invalid threshold")
+)
+
 //go:generate mockery --name WebsiteRepository
 type WebsiteRepository interface {
 	database.WebsiteRepository
@@ -22,23 +37,41 @@ type WebsiteRepository interface {
 type WebsiteService struct {
 	websiteRepository WebsiteRepository
 	scheduler         *crons.Scheduler
+	execEnabled       bool
+	plugins           *processors.PluginRegistry
+}
+
+func NewWebsiteService(websiteRepository database.WebsiteRepository, opts ...func(*WebsiteService) *WebsiteService) *WebsiteService {
+	return transform.Pipe[*WebsiteService](
+		&WebsiteService{
+			websiteRepository: websiteRepository,
+			scheduler:         crons.NewScheduler(),
+		},
+		opts...,
+	)
 }
 
-func NewWebsiteService(websiteRepository database.WebsiteRepository) *WebsiteService {
-	return &WebsiteService{
-		websiteRepository: websiteRepository,
-		scheduler:         crons.NewScheduler(),
+// WithExecEnabled accepts the exec mode websites, it follows the exec flag of the server.
+func WithExecEnabled(enabled bool) func(*WebsiteService) *WebsiteService {
+	return func(w *WebsiteService) *WebsiteService {
+		w.execEnabled = enabled
+		return w
 	}
 }
 
-func (w WebsiteService) Create(ctx context.Context, website domain.Website) (domain.Website, error) {
-	if err := checkMode(website.Mode); err != nil {
-		return domain.Website{}, err
+// WithPlugins sets the registry the plugin steps are validated against, the plugin steps are rejected without it.
+func WithPlugins(plugins *processors.PluginRegistry) func(*WebsiteService) *WebsiteService {
+	return func(w *WebsiteService) *WebsiteService {
+		w.plugins = plugins
+		return w
 	}
+}
 
-	if !validators.IsValidURL(website.URL) {
-		return domain.Website{}, errors.New("invalid url")
+func (w WebsiteService) Create(ctx context.Context, website domain.Website) (domain.Website, error) {
+	if err := w.Validate(website); err != nil {
+		return domain.Website{}, err
 	}
+	website.Setting.Pipeline = website.Setting.ProcessingSteps()
 
 	c := crons.NewScheduler()
 	if err := c.Validate(website.Cron); err != nil {
@@ -55,6 +88,22 @@ func (w WebsiteService) Create(ctx context.Context, website domain.Website) (dom
 	return w.websiteRepository.CreateWebsite(ctx, website)
 }
 
+// Validate checks the mode, the URL and the settings of the website.
+func (w WebsiteService) Validate(website domain.Website) error {
+	if err := checkMode(website.Mode); err != nil {
+		return err
+	}
+	if err := w.checkModeEnabled(website.Mode); err != nil {
+		return err
+	}
+
+	if !validators.IsValidURL(website.URL) {
+		return errors.New("invalid url")
+	}
+
+	return w.validateSetting(website.Mode, website.Setting)
+}
+
 func (w WebsiteService) GetByID(ctx context.Context, id uuid.UUID) (domain.Website, error) {
 	return w.websiteRepository.GetWebsiteByID(ctx, id)
 }
@@ -68,6 +117,14 @@ func (w WebsiteService) GetByUserID(ctx context.Context, userID uuid.UUID, pagin
 }
 
 func (w WebsiteService) Update(ctx context.Context, website domain.Website) (domain.Website, error) {
+	if err := w.checkModeEnabled(website.Mode); err != nil {
+		return domain.Website{}, err
+	}
+	if err := w.validateSetting(website.Mode, website.Setting); err != nil {
+		return domain.Website{}, err
+	}
+	website.Setting.Pipeline = website.Setting.ProcessingSteps()
+
 	return w.websiteRepository.UpdateWebsite(ctx, website)
 }
 
@@ -110,9 +167,87 @@ func (w WebsiteService) Delete(ctx context.Context, id uuid.UUID) error {
 
 func checkMode(mode domain.Mode) error {
 	switch mode {
-	case domain.ModePlain:
+	case domain.ModePlain, domain.ModeExec, domain.ModeCrawl, domain.ModeWorkflow:
 		return nil
 	default:
 		return database.ErrModeNotCorrect
 	}
 }
+
+// checkModeEnabled rejects the modes which are turned off on the server, they would fail on every check.
+func (w WebsiteService) checkModeEnabled(mode domain.Mode) error {
+	if mode == domain.ModeExec && !w.execEnabled {
+		return fmt.Errorf("%w: %s", domain.ErrModeDisabled, mode)
+	}
+	return nil
+}
+
+// validateSetting rejects settings which would fail on every check.
+func (w WebsiteService) validateSetting(mode domain.Mode, setting domain.Setting) error {
+	if mode == domain.ModeExec && setting.Exec.Command == "" {
+		return ErrExecCommandRequired
+	}
+	if !setting.TLS.IsZero() {
+		if _, err := httprequesters.BuildTLSConfig(setting.TLS); err != nil {
+			return err
+		}
+	}
+	if mode == domain.ModeCrawl {
+		if err := validateCrawl(setting.Crawl); err != nil {
+			return err
+		}
+	}
+	if mode == domain.ModeWorkflow {
+		if err := workflow.Validate(setting.Workflow); err != nil {
+			return err
+		}
+	}
+	if err := processors.ValidateSteps(setting.ProcessingSteps(), w.plugins); err != nil {
+		return err
+	}
+	if err := triggers.Validate(setting.Triggers); err != nil {
+		return err
+	}
+	if err := numeric.Validate(setting.Numeric); err != nil {
+		return err
+	}
+	if err := validateThreshold(setting.Threshold); err != nil {
+		return err
+	}
+	if !setting.Pagination.IsZero() {
+		if err := pagination.Validate(setting.Pagination); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateThreshold(conf domain.ThresholdOption) error {
+	if conf.MinChangePercent < 0 || conf.MinChangePercent > 100 {
+		return fmt.Errorf("%w: change percent must be between 0 and 100", ErrInvalidThreshold)
+	}
+	if conf.MinChangedChars < 0 || conf.MinChangedLines < 0 {
+		return fmt.Errorf("%w: changed characters and lines can't be negative", ErrInvalidThreshold)
+	}
+	for _, changeType := range conf.ChangeTypes {
+		switch changeType {
+		case diff.Added, diff.Removed, diff.Modified:
+		default:
+			return fmt.Errorf("%w: unknown change type %s", ErrInvalidThreshold, changeType)
+		}
+	}
+	return nil
+}
+
+func validateCrawl(conf domain.CrawlOption) error {
+	if (conf.MaxDepth != nil && *conf.MaxDepth < 0) || conf.MaxPages < 0 || conf.MaxPages > crawl.MaxPages {
+		return ErrCrawlLimits
+	}
+	if _, err := crawl.CompilePatterns(conf.Include); err != nil {
+		return fmt.Errorf("invalid include pattern: %w", err)
+	}
+	if _, err := crawl.CompilePatterns(conf.Exclude); err != nil {
+		return fmt.Errorf("invalid exclude pattern: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"github.com/gelleson/changescout/changescout/internal/app/services/diff"
 	"github.com/gelleson/changescout/changescout/internal/app/services/mocks"
 	"github.com/gelleson/changescout/changescout/internal/domain"
 	"github.com/gelleson/changescout/changescout/internal/infrastructure/database"
@@ -23,7 +24,7 @@ type WebsiteServiceTestSuite struct {
 
 func (s *WebsiteServiceTestSuite) SetupTest() {
 	s.mockRepo = mocks.NewWebsiteRepository(s.T())
-	s.service = NewWebsiteService(s.mockRepo)
+	s.service = NewWebsiteService(s.mockRepo, WithExecEnabled(true))
 	s.ctx = context.Background()
 }
 
@@ -114,6 +115,247 @@ func (s *WebsiteServiceTestSuite) TestCreate() {
 			mock:    func() {},
 			wantErr: true,
 		},
+		{
+			name: "exec mode without command",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModeExec,
+				Enabled: true,
+				Cron:    "* * * * *",
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "crawl mode with invalid pattern",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModeCrawl,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Crawl: domain.CrawlOption{Exclude: []string{"("}},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "ambiguous pagination",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Pagination: domain.PaginationOption{
+						NextSelector: "a.next",
+						PageParam:    "page",
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "workflow without steps",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModeWorkflow,
+				Enabled: true,
+				Cron:    "* * * * *",
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "invalid regex pattern",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Regex: domain.RegexOption{Patterns: []string{"v(\\d+"}},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "invalid jq expression",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					JQ: ".items[",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "script without process function",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Script: "def transform(body):\n    return body\n",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "unknown plugin",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Plugin: "missing",
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "invalid sort key pattern",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Pipeline: []domain.ProcessingStep{
+						{Type: domain.StepSort, Sort: domain.SortOption{KeyPattern: "("}},
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "invalid filter regex",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Filter: domain.FilterOption{Include: []string{"("}, Regex: true},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "invalid trigger",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Triggers: []domain.Trigger{{Type: domain.TriggerAppears}},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "unknown numeric locale",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Numeric: domain.NumericOption{Enabled: true, Selector: ".price", Locale: "xx"},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "unknown threshold change type",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Threshold: domain.ThresholdOption{ChangeTypes: []diff.ChangeType{"moved"}},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "negative threshold",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Threshold: domain.ThresholdOption{MinChangedLines: -1},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "unknown processing step",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					Pipeline: []domain.ProcessingStep{{Type: "unknown"}},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
+		{
+			name: "invalid tls setting",
+			website: domain.Website{
+				URL:     "https://example.com",
+				UserID:  uuid.New(),
+				Mode:    domain.ModePlain,
+				Enabled: true,
+				Cron:    "* * * * *",
+				Setting: domain.Setting{
+					TLS: domain.TLSOption{
+						CACertificates: []string{"not a pem"},
+					},
+				},
+			},
+			mock:    func() {},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -135,6 +377,26 @@ func (s *WebsiteServiceTestSuite) TestCreate() {
 }
 
 // Тестирование метода UpdateLastCheck
+func (s *WebsiteServiceTestSuite) TestExecModeDisabled() {
+	service := NewWebsiteService(s.mockRepo)
+	website := domain.Website{
+		URL:     "https://example.com",
+		UserID:  uuid.New(),
+		Mode:    domain.ModeExec,
+		Enabled: true,
+		Cron:    "* * * * *",
+		Setting: domain.Setting{Exec: domain.ExecOption{Command: "df"}},
+	}
+
+	_, err := service.Create(s.ctx, website)
+	s.ErrorIs(err, domain.ErrModeDisabled)
+
+	_, err = service.Update(s.ctx, website)
+	s.ErrorIs(err, domain.ErrModeDisabled)
+	s.mockRepo.AssertNotCalled(s.T(), "CreateWebsite", mock.Anything, mock.Anything)
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateWebsite", mock.Anything, mock.Anything)
+}
+
 func (s *WebsiteServiceTestSuite) TestUpdateLastCheck() {
 	baseTime := time.Now()
 	tests := []struct {
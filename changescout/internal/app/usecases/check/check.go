@@ -2,12 +2,20 @@ package check
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gelleson/changescout/changescout/internal/app/services/diff"
 	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/infrastructure/database"
+	"github.com/gelleson/changescout/changescout/internal/pkg/numeric"
 	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/gelleson/changescout/changescout/internal/pkg/triggers"
+	"github.com/gelleson/changescout/changescout/internal/utils/transform"
 	"github.com/google/uuid"
 	"net/http"
+	"strings"
+	"time"
 )
 
 //go:generate mockery --name Doer
@@ -18,6 +26,7 @@ type Doer interface {
 //go:generate mockery --name WebsiteService
 type WebsiteService interface {
 	GetByID(ctx context.Context, id uuid.UUID) (domain.Website, error)
+	Validate(website domain.Website) error
 }
 
 //go:generate mockery --name HttpService
@@ -25,15 +34,26 @@ type HttpService interface {
 	Request(site domain.Website) ([]byte, error)
 }
 
+// CommandService is an HttpService which reports the stderr of the successful exec mode commands too.
+//
+//go:generate mockery --name CommandService
+type CommandService interface {
+	HttpService
+	Command(site domain.Website) (domain.CommandOutput, error)
+}
+
 //go:generate mockery --name DiffService
 type DiffService interface {
 	Compare(previous, current []byte) (diff.Result, error)
+	CompareJSON(previous, current []byte, keyField string) (diff.Result, error)
+	ComparePages(previous, current []byte) (diff.Result, error)
 }
 
 //go:generate mockery --name DBService
 type DBService interface {
 	GetLatestCheckByWebsite(ctx context.Context, websiteID uuid.UUID) (domain.Check, error)
 	CreateCheck(ctx context.Context, check domain.Check) (domain.Check, error)
+	GetSeries(ctx context.Context, filters database.CheckFilters) ([]domain.SeriesPoint, error)
 }
 
 type UseCase struct {
@@ -41,19 +61,23 @@ type UseCase struct {
 	httpService    HttpService
 	checkService   DBService
 	diffService    DiffService
+	plugins        *processors.PluginRegistry
 }
 
+// NewUseCase builds the use case, the plugin steps run the plugins of the registry which may be nil.
 func NewUseCase(
 	websiteService WebsiteService,
 	httpService HttpService,
 	checkService DBService,
 	diffService DiffService,
+	plugins *processors.PluginRegistry,
 ) *UseCase {
 	return &UseCase{
 		websiteService: websiteService,
 		httpService:    httpService,
 		checkService:   checkService,
 		diffService:    diffService,
+		plugins:        plugins,
 	}
 }
 
@@ -63,23 +87,58 @@ func (u UseCase) View(ctx context.Context, websiteID uuid.UUID) ([]byte, error)
 		return nil, fmt.Errorf("failed to get website: %w", err)
 	}
 
+	body, _, _, err := u.view(ctx, site)
+	return body, err
+}
+
+// view fetches and processes the content of the website, significant is the verdict of the processors if any.
+// The stderr is the one of the exec mode command.
+func (u UseCase) view(ctx context.Context, site domain.Website) (body []byte, stderr string, significant *bool, err error) {
 	// Make HTTP request
-	body, err := u.makeRequestAndHandleError(ctx, site)
+	output, err := u.makeRequestAndHandleError(ctx, site)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	body = output.Stdout
+
+	processor := processors.New(processors.FromSteps(site.Setting.ProcessingSteps(), u.plugins)...)
+
+	if site.Mode == domain.ModeCrawl {
+		body, err = processPages(body, processor)
+	} else {
+		body, err = processor.Run(body)
+	}
+	if err != nil {
+		return nil, "", nil, &domain.ProcessingError{Err: err}
+	}
+
+	return body, output.Stderr, processor.Significant(), nil
+}
+
+// Debug runs the processing pipeline of the website stage by stage and returns the output of every stage.
+// A failed stage is reported in the trace instead of the error, so the pipeline can be fixed.
+// Nothing is persisted, a failed request is not recorded in the history of the website.
+func (u UseCase) Debug(site domain.Website) (domain.PipelineTrace, error) {
+	body, err := u.httpService.Request(site)
 	if err != nil {
-		return nil, err
+		return domain.PipelineTrace{}, fmt.Errorf("failed to make HTTP request: %w", err)
 	}
 
-	processor := processors.New(
-		processors.NewHTMLProcessor(site.Setting),
-		processors.NewJSONPathProcessor(site.Setting),
-		processors.NewDeduplicationProcessor(site.Setting),
-		processors.NewTrimProcessor(site.Setting),
-		processors.NewSortProcessor(site.Setting),
-	)
+	return trace(site, body, u.plugins), nil
+}
+
+// Preview works as Debug for a website which is not saved, nothing is persisted.
+func (u UseCase) Preview(site domain.Website) (domain.PipelineTrace, error) {
+	if err := u.websiteService.Validate(site); err != nil {
+		return domain.PipelineTrace{}, err
+	}
 
-	body = processor.Run(body)
+	body, err := u.httpService.Request(site)
+	if err != nil {
+		return domain.PipelineTrace{}, fmt.Errorf("failed to make HTTP request: %w", err)
+	}
 
-	return body, nil
+	return trace(site, body, u.plugins), nil
 }
 
 func (u UseCase) Check(ctx context.Context, websiteID uuid.UUID) (domain.CheckResult, error) {
@@ -90,88 +149,305 @@ func (u UseCase) Check(ctx context.Context, websiteID uuid.UUID) (domain.CheckRe
 	}
 
 	// Make HTTP request
-	body, err := u.View(ctx, site.ID)
+	body, stderr, significant, viewErr := u.view(ctx, site)
+	var value *float64
+	if viewErr == nil {
+		value, viewErr = extractValue(site.Setting.Numeric, body)
+	}
+	if viewErr != nil && !domain.IsErrProcessing(viewErr) {
+		return domain.CheckResult{}, viewErr
+	}
+
+	latestCheck, err := u.getLatestCheck(ctx, site.ID)
 	if err != nil {
 		return domain.CheckResult{}, err
 	}
 
+	if viewErr != nil {
+		return u.handleProcessingError(ctx, site, latestCheck, viewErr)
+	}
+
+	// The pages of the crawl which failed to fetch keep their previous content
+	var failedPages []string
+	if site.Mode == domain.ModeCrawl {
+		body, failedPages, err = carryOverPages(latestCheck.Result, body)
+		if err != nil {
+			return domain.CheckResult{}, err
+		}
+	}
+
 	// Compare with previous check
-	diffResult, prevResult, err := u.compareWithPreviousCheck(ctx, site.ID, body)
+	diffResult, err := u.compareWithPreviousCheck(site, latestCheck, body)
 	if err != nil {
 		return domain.CheckResult{}, err
 	}
 
-	// If no changes, return early
-	if !diffResult.HasChanges {
+	// If no changes, return early unless the processing recovered from a failure
+	recovered := latestCheck.Status == domain.CheckStatusProcessingFailed
+	if !diffResult.HasChanges && !recovered {
 		return domain.CheckResult{}, nil
 	}
 
 	// Create new check record
-	if err := u.createSuccessfulCheck(ctx, site.ID, body, diffResult); err != nil {
+	if err := u.createSuccessfulCheck(ctx, site, body, stderr, failedPages, value, diffResult); err != nil {
 		return domain.CheckResult{}, err
 	}
 
+	// The change is recorded in the history, but not notified when the script marked it as not significant
+	if !diffResult.HasChanges || (significant != nil && !*significant) {
+		return domain.CheckResult{}, nil
+	}
+
+	// Nor when it is below the threshold of the website
+	if !site.Setting.Threshold.Exceeded(diffResult) {
+		return domain.CheckResult{}, nil
+	}
+
+	// Nor when the website has triggers or numeric conditions and none of them fired
+	fired, err := triggers.Evaluate(site.Setting.Triggers, latestCheck.Result, body)
+	if err != nil {
+		return domain.CheckResult{}, fmt.Errorf("failed to evaluate triggers: %w", err)
+	}
+	conditions := site.Setting.Numeric.HasConditions()
+	crossed := conditions && value != nil && numeric.Fires(site.Setting.Numeric, latestCheck.Value, *value)
+	if (len(site.Setting.Triggers) > 0 || conditions) && len(fired) == 0 && !crossed {
+		return domain.CheckResult{}, nil
+	}
+
 	return domain.CheckResult{
-		OldValue:   prevResult,
-		NewValue:   body,
-		HasChanges: diffResult.HasChanges,
-		Check:      diffResult,
+		OldValue:      latestCheck.Result,
+		NewValue:      body,
+		HasChanges:    diffResult.HasChanges,
+		Check:         diffResult,
+		Triggered:     fired,
+		Value:         value,
+		PreviousValue: latestCheck.Value,
 	}, nil
 }
 
+// Series returns the values extracted by the numeric setting of the website between the dates, in chronological order.
+func (u UseCase) Series(ctx context.Context, websiteID uuid.UUID, from, to *time.Time) ([]domain.SeriesPoint, error) {
+	points, err := u.checkService.GetSeries(ctx, database.CheckFilters{
+		WebsiteID: &websiteID,
+		FromDate:  from,
+		ToDate:    to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+	return points, nil
+}
+
+// extractValue returns the number selected by the numeric setting, nil when the setting is disabled.
+// A number which can't be extracted is a processing error, like a selector which matches nothing.
+func extractValue(conf domain.NumericOption, body []byte) (*float64, error) {
+	if !conf.Enabled {
+		return nil, nil
+	}
+
+	value, err := numeric.Extract(body, conf)
+	if err != nil {
+		return nil, &domain.ProcessingError{Err: fmt.Errorf("failed to extract number: %w", err)}
+	}
+	return &value, nil
+}
+
+// handleProcessingError records the first failure of the processors and flags it for the alert,
+// the repeated failures are not recorded until the processing recovers.
+func (u UseCase) handleProcessingError(ctx context.Context, site domain.Website, latestCheck domain.Check, processingErr error) (domain.CheckResult, error) {
+	if latestCheck.Status == domain.CheckStatusProcessingFailed {
+		return domain.CheckResult{}, nil
+	}
+
+	// Keep the last known result, so the recovered content is compared against it instead of nothing
+	check := domain.Check{
+		WebsiteID:    site.ID,
+		Result:       latestCheck.Result,
+		Value:        latestCheck.Value,
+		DiffResult:   &diff.Result{},
+		HasError:     true,
+		Status:       domain.CheckStatusProcessingFailed,
+		ErrorMessage: processingErr.Error(),
+	}
+	if _, err := u.checkService.CreateCheck(ctx, check); err != nil {
+		return domain.CheckResult{}, fmt.Errorf("failed to create processing error check: %w (original error: %v)", err, processingErr)
+	}
+
+	return domain.CheckResult{ProcessingError: processingErr}, nil
+}
+
 // makeRequestAndHandleError handles the HTTP request and records any errors
-func (u UseCase) makeRequestAndHandleError(ctx context.Context, site domain.Website) ([]byte, error) {
-	body, err := u.httpService.Request(site)
+func (u UseCase) makeRequestAndHandleError(ctx context.Context, site domain.Website) (domain.CommandOutput, error) {
+	output, err := u.request(site)
 	if err != nil {
 		if createErr := u.createFailedCheck(ctx, site.ID, err); createErr != nil {
-			return nil, fmt.Errorf("failed to create error check: %w (original error: %v)", createErr, err)
+			return domain.CommandOutput{}, fmt.Errorf("failed to create error check: %w (original error: %v)", createErr, err)
 		}
-		return nil, fmt.Errorf("failed to make HTTP request: %w", err)
+		return domain.CommandOutput{}, fmt.Errorf("failed to make HTTP request: %w", err)
 	}
-	return body, nil
+	return output, nil
 }
 
-// compareWithPreviousCheck gets the latest check and compares results
-func (u UseCase) compareWithPreviousCheck(ctx context.Context, websiteID uuid.UUID, currentBody []byte) (diff.Result, []byte, error) {
+// request fetches the content of the website, the content is the stdout for the exec mode
+func (u UseCase) request(site domain.Website) (domain.CommandOutput, error) {
+	if commands, ok := u.httpService.(CommandService); ok && site.Mode == domain.ModeExec {
+		return commands.Command(site)
+	}
+
+	body, err := u.httpService.Request(site)
+	return domain.CommandOutput{Stdout: body}, err
+}
+
+// getLatestCheck returns the latest check of the website, or an empty check for the first run
+func (u UseCase) getLatestCheck(ctx context.Context, websiteID uuid.UUID) (domain.Check, error) {
 	latestCheck, err := u.checkService.GetLatestCheckByWebsite(ctx, websiteID)
 	if err != nil && !domain.IsErrCheckNotFound(err) {
-		return diff.Result{}, nil, fmt.Errorf("failed to get latest check: %w", err)
+		return domain.Check{}, fmt.Errorf("failed to get latest check: %w", err)
 	}
+	return latestCheck, nil
+}
 
-	var prevResult []byte
-	if latestCheck.Result != nil {
-		prevResult = latestCheck.Result
+// compareWithPreviousCheck compares the current results with the latest check
+func (u UseCase) compareWithPreviousCheck(site domain.Website, latestCheck domain.Check, currentBody []byte) (diff.Result, error) {
+	compare := u.diffService.Compare
+	switch {
+	case site.Mode == domain.ModeCrawl:
+		compare = u.diffService.ComparePages
+	case site.Setting.Diff.JSONArrayKey != "":
+		compare = func(previous, current []byte) (diff.Result, error) {
+			return u.diffService.CompareJSON(previous, current, site.Setting.Diff.JSONArrayKey)
+		}
 	}
 
-	diffResult, err := u.diffService.Compare(prevResult, currentBody)
+	diffResult, err := compare(latestCheck.Result, currentBody)
 	if err != nil {
-		return diff.Result{}, nil, fmt.Errorf("failed to compare results: %w", err)
+		return diff.Result{}, fmt.Errorf("failed to compare results: %w", err)
+	}
+
+	return diffResult, nil
+}
+
+// trace runs the processing stages of the website one by one and stops at the failed stage
+func trace(site domain.Website, body []byte, plugins *processors.PluginRegistry) domain.PipelineTrace {
+	var err error
+	result := domain.PipelineTrace{Raw: body}
+	for _, stage := range processors.StagesFromSteps(site.Setting.ProcessingSteps(), plugins) {
+		processor := processors.New(stage.Processor)
+		started := time.Now()
+
+		if site.Mode == domain.ModeCrawl {
+			body, err = processPages(body, processor)
+		} else {
+			body, err = processor.Run(body)
+		}
+
+		stageTrace := domain.StageTrace{
+			Type:    stage.Type,
+			Output:  body,
+			Elapsed: time.Since(started),
+		}
+		if err != nil {
+			stageTrace.Error = err.Error()
+			result.Stages = append(result.Stages, stageTrace)
+			return result
+		}
+		result.Stages = append(result.Stages, stageTrace)
+	}
+	result.Result = body
+
+	return result
+}
+
+// processPages runs the processors on every page of the crawl mode snapshot
+func processPages(body []byte, processor processors.ProcessRunner) ([]byte, error) {
+	var pages domain.PageSnapshot
+	if err := json.Unmarshal(body, &pages); err != nil {
+		return nil, fmt.Errorf("failed to decode pages: %w", err)
 	}
 
-	return diffResult, prevResult, nil
+	for url, page := range pages {
+		if domain.IsPageError(page) {
+			continue
+		}
+		processed, err := processor.Run([]byte(page))
+		if err != nil {
+			return nil, fmt.Errorf("page %s: %w", url, err)
+		}
+		pages[url] = string(processed)
+	}
+
+	return json.Marshal(pages)
+}
+
+// carryOverPages replaces the pages of the crawl mode snapshot which failed to fetch with their previous content,
+// it returns the snapshot and the URLs of the failed pages.
+func carryOverPages(previous, body []byte) ([]byte, []string, error) {
+	var pages domain.PageSnapshot
+	if err := json.Unmarshal(body, &pages); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode pages: %w", err)
+	}
+
+	var previousPages domain.PageSnapshot
+	if len(previous) > 0 {
+		// A previous result which is not a snapshot, e.g. after a change of mode, has no page to carry over
+		_ = json.Unmarshal(previous, &previousPages)
+	}
+
+	failed := pages.CarryOver(previousPages)
+	if len(failed) == 0 {
+		return body, nil, nil
+	}
+
+	body, err := json.Marshal(pages)
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, failed, nil
 }
 
 // createFailedCheck creates a check record for a failed request
 func (u UseCase) createFailedCheck(ctx context.Context, websiteID uuid.UUID, requestError error) error {
-	_, err := u.checkService.CreateCheck(ctx, domain.Check{
+	check := domain.Check{
 		WebsiteID:    websiteID,
 		Result:       nil,
 		DiffResult:   &diff.Result{},
 		HasChanges:   true,
 		HasError:     true,
+		Status:       domain.CheckStatusRequestFailed,
 		ErrorMessage: requestError.Error(),
-	})
+	}
+
+	var cmdErr *domain.CommandError
+	if errors.As(requestError, &cmdErr) {
+		check.ExitCode = &cmdErr.ExitCode
+		check.Stderr = cmdErr.Stderr
+	}
+
+	_, err := u.checkService.CreateCheck(ctx, check)
 	return err
 }
 
-// createSuccessfulCheck creates a check record for a successful comparison
-func (u UseCase) createSuccessfulCheck(ctx context.Context, websiteID uuid.UUID, body []byte, diffResult diff.Result) error {
-	_, err := u.checkService.CreateCheck(ctx, domain.Check{
-		WebsiteID:  websiteID,
+// createSuccessfulCheck creates a check record for a successful comparison,
+// the failed pages of the crawl mode are recorded in the error message.
+func (u UseCase) createSuccessfulCheck(ctx context.Context, site domain.Website, body []byte, stderr string, failedPages []string, value *float64, diffResult diff.Result) error {
+	check := domain.Check{
+		WebsiteID:  site.ID,
 		Result:     body,
+		Value:      value,
 		DiffResult: &diffResult,
-		HasChanges: true,
+		HasChanges: diffResult.HasChanges,
 		HasError:   false,
-	})
+		Status:     domain.CheckStatusOK,
+	}
+
+	if site.Mode == domain.ModeExec {
+		check.ExitCode = transform.ToPtr(0)
+		check.Stderr = stderr
+	}
+	if len(failedPages) > 0 {
+		check.ErrorMessage = "failed pages: " + strings.Join(failedPages, ", ")
+	}
+
+	_, err := u.checkService.CreateCheck(ctx, check)
 	return err
 }
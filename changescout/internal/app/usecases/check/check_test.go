@@ -2,15 +2,22 @@ package check
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gelleson/changescout/changescout/internal/app/services/diff"
 	"github.com/gelleson/changescout/changescout/internal/app/usecases/check/mocks"
 	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/infrastructure/database"
+	"github.com/gelleson/changescout/changescout/internal/pkg/numeric"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/gelleson/changescout/changescout/internal/utils/transform"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type CheckTestSuite struct {
@@ -35,6 +42,7 @@ func (s *CheckTestSuite) SetupTest() {
 		s.httpService,
 		s.checkService,
 		s.diffService,
+		nil,
 	)
 }
 
@@ -106,7 +114,8 @@ func (s *CheckTestSuite) TestCheckWithChanges() {
 		return check.WebsiteID == websiteID &&
 			string(check.Result) == string(currentContent) &&
 			check.HasChanges == true &&
-			check.HasError == false
+			check.HasError == false &&
+			check.Status == domain.CheckStatusOK
 	})).Return(domain.Check{}, nil)
 
 	// Act
@@ -159,6 +168,7 @@ func (s *CheckTestSuite) TestRequestError() {
 	s.checkService.On("CreateCheck", s.ctx, mock.MatchedBy(func(check domain.Check) bool {
 		return check.WebsiteID == websiteID &&
 			check.HasError == true &&
+			check.Status == domain.CheckStatusRequestFailed &&
 			check.ErrorMessage == requestErr.Error()
 	})).Return(domain.Check{}, nil)
 
@@ -175,6 +185,70 @@ func (s *CheckTestSuite) TestRequestError() {
 	s.checkService.AssertExpectations(s.T())
 }
 
+func (s *CheckTestSuite) TestRequestCommandError() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:   websiteID,
+		Mode: domain.ModeExec,
+	}
+	requestErr := &domain.CommandError{
+		ExitCode: 2,
+		Stderr:   "permission denied",
+		Err:      fmt.Errorf("exit status 2"),
+	}
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return(nil, requestErr)
+	s.checkService.On("CreateCheck", s.ctx, mock.MatchedBy(func(check domain.Check) bool {
+		return check.WebsiteID == websiteID &&
+			check.HasError == true &&
+			check.ExitCode != nil && *check.ExitCode == 2 &&
+			check.Stderr == "permission denied"
+	})).Return(domain.Check{}, nil)
+
+	// Act
+	result, err := s.useCase.Check(s.ctx, websiteID)
+
+	// Assert
+	assert.Error(s.T(), err)
+	assert.ErrorAs(s.T(), err, &requestErr)
+	assert.Empty(s.T(), result)
+	s.websiteService.AssertExpectations(s.T())
+	s.httpService.AssertExpectations(s.T())
+	s.checkService.AssertExpectations(s.T())
+}
+
+func (s *CheckTestSuite) TestExecCheckKeepsStderr() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:   websiteID,
+		Mode: domain.ModeExec,
+	}
+	commandService := mocks.NewCommandService(s.T())
+	useCase := NewUseCase(s.websiteService, commandService, s.checkService, s.diffService, nil)
+	stdout := []byte("42")
+	diffResult := diff.Result{HasChanges: true}
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	commandService.On("Command", website).Return(domain.CommandOutput{Stdout: stdout, Stderr: "deprecated flag"}, nil)
+	s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(domain.Check{}, domain.ErrCheckNotFound)
+	s.diffService.On("Compare", []byte(nil), stdout).Return(diffResult, nil)
+	s.checkService.On("CreateCheck", s.ctx, mock.MatchedBy(func(check domain.Check) bool {
+		return check.ExitCode != nil && *check.ExitCode == 0 &&
+			check.Stderr == "deprecated flag" &&
+			string(check.Result) == "42"
+	})).Return(domain.Check{}, nil)
+
+	// Act
+	_, err := useCase.Check(s.ctx, websiteID)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	commandService.AssertNotCalled(s.T(), "Request", mock.Anything)
+}
+
 func (s *CheckTestSuite) TestCompareError() {
 	// Arrange
 	websiteID := uuid.New()
@@ -297,6 +371,582 @@ func (s *CheckTestSuite) TestViewSuccess() {
 	s.httpService.AssertExpectations(s.T())
 }
 
+func (s *CheckTestSuite) TestDebugTracesEveryStage() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:  websiteID,
+		URL: "https://example.com",
+		Setting: domain.Setting{
+			Pipeline: []domain.ProcessingStep{
+				{Type: domain.StepHTML, Selectors: []string{"li"}},
+				{Type: domain.StepSort},
+			},
+		},
+	}
+	raw := []byte(`<html><body><ul><li>b</li><li>a</li></ul></body></html>`)
+
+	s.httpService.On("Request", website).Return(raw, nil)
+
+	// Act
+	trace, err := s.useCase.Debug(website)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), raw, trace.Raw)
+	assert.Len(s.T(), trace.Stages, 2)
+	assert.Equal(s.T(), domain.StepHTML, trace.Stages[0].Type)
+	assert.Equal(s.T(), "b\na", string(trace.Stages[0].Output))
+	assert.Equal(s.T(), domain.StepSort, trace.Stages[1].Type)
+	assert.Equal(s.T(), trace.Stages[1].Output, trace.Result)
+	assert.Empty(s.T(), trace.Stages[1].Error)
+}
+
+func (s *CheckTestSuite) TestDebugStopsAtFailedStage() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:  websiteID,
+		URL: "https://example.com",
+		Setting: domain.Setting{
+			Pipeline: []domain.ProcessingStep{
+				{Type: domain.StepHTML, Selectors: []string{".price"}},
+				{Type: domain.StepTrim},
+			},
+		},
+	}
+
+	s.httpService.On("Request", website).Return([]byte(`<html><body><p>a</p></body></html>`), nil)
+
+	// Act
+	trace, err := s.useCase.Debug(website)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), trace.Stages, 1)
+	assert.Contains(s.T(), trace.Stages[0].Error, processors.ErrNoMatches.Error())
+	assert.Nil(s.T(), trace.Result)
+}
+
+func (s *CheckTestSuite) TestDebugDoesNotRecordFailedRequest() {
+	// Arrange
+	website := domain.Website{ID: uuid.New(), URL: "https://example.com"}
+	s.httpService.On("Request", website).Return(nil, errors.New("connection refused"))
+
+	// Act
+	_, err := s.useCase.Debug(website)
+
+	// Assert
+	assert.Error(s.T(), err)
+	s.checkService.AssertNotCalled(s.T(), "CreateCheck", mock.Anything, mock.Anything)
+}
+
+func (s *CheckTestSuite) TestPreviewDoesNotPersist() {
+	// Arrange
+	website := domain.Website{
+		URL:  "https://example.com",
+		Mode: domain.ModePlain,
+		Setting: domain.Setting{
+			Selectors: []string{"h1"},
+		},
+	}
+
+	s.websiteService.On("Validate", website).Return(nil)
+	s.httpService.On("Request", website).Return([]byte(`<h1>Title</h1>`), nil)
+
+	// Act
+	trace, err := s.useCase.Preview(website)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "Title", string(trace.Result))
+	s.checkService.AssertNotCalled(s.T(), "CreateCheck", mock.Anything, mock.Anything)
+}
+
+func (s *CheckTestSuite) TestPreviewErrors() {
+	// Arrange
+	invalid := domain.Website{URL: "invalid"}
+	failing := domain.Website{URL: "https://example.com"}
+	validationErr := errors.New("invalid url")
+
+	s.websiteService.On("Validate", invalid).Return(validationErr)
+	s.websiteService.On("Validate", failing).Return(nil)
+	s.httpService.On("Request", failing).Return(nil, domain.ErrRequestFailed)
+
+	// Act
+	_, invalidErr := s.useCase.Preview(invalid)
+	_, requestErr := s.useCase.Preview(failing)
+
+	// Assert
+	assert.ErrorIs(s.T(), invalidErr, validationErr)
+	assert.ErrorIs(s.T(), requestErr, domain.ErrRequestFailed)
+	s.httpService.AssertNumberOfCalls(s.T(), "Request", 1)
+	s.checkService.AssertNotCalled(s.T(), "CreateCheck", mock.Anything, mock.Anything)
+}
+
+func (s *CheckTestSuite) TestCheckInsignificantChange() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:  websiteID,
+		URL: "https://example.com",
+		Setting: domain.Setting{
+			Script: "def process(body):\n    return body, int(body) > 100\n",
+		},
+	}
+	previousContent := []byte("40")
+	currentContent := []byte("42")
+	diffResult := diff.Result{HasChanges: true}
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return(currentContent, nil)
+	s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(domain.Check{Result: previousContent}, nil)
+	s.diffService.On("Compare", previousContent, currentContent).Return(diffResult, nil)
+	s.checkService.On("CreateCheck", s.ctx, mock.MatchedBy(func(check domain.Check) bool {
+		return check.HasChanges && string(check.Result) == string(currentContent)
+	})).Return(domain.Check{}, nil)
+
+	// Act
+	result, err := s.useCase.Check(s.ctx, websiteID)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), result.HasChanges)
+	s.checkService.AssertExpectations(s.T())
+}
+
+func (s *CheckTestSuite) TestCheckTriggers() {
+	tests := []struct {
+		name     string
+		current  []byte
+		triggers []domain.Trigger
+		notified bool
+	}{
+		{
+			name:     "Trigger fires",
+			current:  []byte("In stock"),
+			triggers: []domain.Trigger{{Type: domain.TriggerDisappears, Value: "Sold out"}},
+			notified: true,
+		},
+		{
+			name:     "No trigger fires",
+			current:  []byte("Sold out, back soon"),
+			triggers: []domain.Trigger{{Type: domain.TriggerDisappears, Value: "Sold out"}},
+			notified: false,
+		},
+		{
+			name:     "Without triggers",
+			current:  []byte("Sold out, back soon"),
+			notified: true,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+
+			// Arrange
+			websiteID := uuid.New()
+			website := domain.Website{
+				ID:      websiteID,
+				URL:     "https://example.com",
+				Setting: domain.Setting{Triggers: tt.triggers},
+			}
+			previousContent := []byte("Sold out")
+			diffResult := diff.Result{HasChanges: true}
+
+			s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+			s.httpService.On("Request", website).Return(tt.current, nil)
+			s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(domain.Check{Result: previousContent}, nil)
+			s.diffService.On("Compare", previousContent, tt.current).Return(diffResult, nil)
+			s.checkService.On("CreateCheck", s.ctx, mock.MatchedBy(func(check domain.Check) bool {
+				return check.HasChanges && string(check.Result) == string(tt.current)
+			})).Return(domain.Check{}, nil)
+
+			// Act
+			result, err := s.useCase.Check(s.ctx, websiteID)
+
+			// Assert
+			assert.NoError(s.T(), err)
+			assert.Equal(s.T(), tt.notified, result.HasChanges)
+			if len(tt.triggers) > 0 && tt.notified {
+				assert.Equal(s.T(), tt.triggers, result.Triggered)
+			}
+			s.checkService.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *CheckTestSuite) TestCheckJSONArrayKey() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:  websiteID,
+		URL: "https://example.com",
+		Setting: domain.Setting{
+			Diff: domain.DiffOption{JSONArrayKey: "id"},
+		},
+	}
+	previousContent := []byte(`[{"id":1,"price":5}]`)
+	currentContent := []byte(`[{"id":1,"price":6}]`)
+	diffResult := diff.Result{
+		HasChanges: true,
+		Changes:    []diff.Change{{Type: diff.Modified, Path: "/0/price", Content: "6", OldValue: "5", NewValue: "6"}},
+	}
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return(currentContent, nil)
+	s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(domain.Check{Result: previousContent}, nil)
+	s.diffService.On("CompareJSON", previousContent, currentContent, "id").Return(diffResult, nil)
+	s.checkService.On("CreateCheck", s.ctx, mock.Anything).Return(domain.Check{}, nil)
+
+	// Act
+	result, err := s.useCase.Check(s.ctx, websiteID)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), diffResult, result.Check)
+	s.diffService.AssertNotCalled(s.T(), "Compare", mock.Anything, mock.Anything)
+}
+
+func (s *CheckTestSuite) TestCheckBelowThreshold() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:  websiteID,
+		URL: "https://example.com",
+		Setting: domain.Setting{
+			Threshold: domain.ThresholdOption{MinChangePercent: 5},
+		},
+	}
+	previousContent := []byte("Visitors: 1000")
+	currentContent := []byte("Visitors: 1001")
+	diffResult := diff.Result{HasChanges: true, ChangePercent: 3.5}
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return(currentContent, nil)
+	s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(domain.Check{Result: previousContent}, nil)
+	s.diffService.On("Compare", previousContent, currentContent).Return(diffResult, nil)
+	s.checkService.On("CreateCheck", s.ctx, mock.MatchedBy(func(check domain.Check) bool {
+		return check.HasChanges && string(check.Result) == string(currentContent)
+	})).Return(domain.Check{}, nil)
+
+	// Act
+	result, err := s.useCase.Check(s.ctx, websiteID)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), result.HasChanges)
+	s.checkService.AssertExpectations(s.T())
+}
+
+func (s *CheckTestSuite) TestCheckNumericConditions() {
+	tests := []struct {
+		name     string
+		previous *float64
+		current  []byte
+		notified bool
+	}{
+		{
+			name:     "Crosses threshold",
+			previous: transform.ToPtr(105.0),
+			current:  []byte("Price: 99,90 €"),
+			notified: true,
+		},
+		{
+			name:     "Stays beyond threshold",
+			previous: transform.ToPtr(95.0),
+			current:  []byte("Price: 99,90 €"),
+			notified: false,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+
+			// Arrange
+			websiteID := uuid.New()
+			website := domain.Website{
+				ID:  websiteID,
+				URL: "https://example.com",
+				Setting: domain.Setting{
+					Numeric: domain.NumericOption{Enabled: true, Locale: "de", Below: transform.ToPtr(100.0)},
+				},
+			}
+			previousContent := []byte("Price: old")
+			diffResult := diff.Result{HasChanges: true}
+
+			s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+			s.httpService.On("Request", website).Return(tt.current, nil)
+			s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(domain.Check{
+				Result: previousContent,
+				Value:  tt.previous,
+			}, nil)
+			s.diffService.On("Compare", previousContent, tt.current).Return(diffResult, nil)
+			s.checkService.On("CreateCheck", s.ctx, mock.MatchedBy(func(check domain.Check) bool {
+				return check.Value != nil && *check.Value == 99.9
+			})).Return(domain.Check{}, nil)
+
+			// Act
+			result, err := s.useCase.Check(s.ctx, websiteID)
+
+			// Assert
+			assert.NoError(s.T(), err)
+			assert.Equal(s.T(), tt.notified, result.HasChanges)
+			if tt.notified {
+				assert.Equal(s.T(), 99.9, *result.Value)
+				assert.Equal(s.T(), tt.previous, result.PreviousValue)
+			}
+			s.checkService.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *CheckTestSuite) TestCheckNumericExtractionFailed() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:  websiteID,
+		URL: "https://example.com",
+		Setting: domain.Setting{
+			Numeric: domain.NumericOption{Enabled: true},
+		},
+	}
+	previous := domain.Check{Result: []byte("42"), Value: transform.ToPtr(42.0), Status: domain.CheckStatusOK}
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return([]byte("Sold out"), nil)
+	s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(previous, nil)
+	s.checkService.On("CreateCheck", s.ctx, mock.MatchedBy(func(check domain.Check) bool {
+		return check.Status == domain.CheckStatusProcessingFailed && check.Value != nil && *check.Value == 42
+	})).Return(domain.Check{}, nil)
+
+	// Act
+	result, err := s.useCase.Check(s.ctx, websiteID)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.ErrorIs(s.T(), result.ProcessingError, numeric.ErrNoNumber)
+}
+
+func (s *CheckTestSuite) TestSeries() {
+	// Arrange
+	websiteID := uuid.New()
+	from := time.Now().Add(-time.Hour)
+	points := []domain.SeriesPoint{{Value: 10, CreatedAt: from.Add(time.Minute)}}
+
+	s.checkService.On("GetSeries", s.ctx, database.CheckFilters{WebsiteID: &websiteID, FromDate: &from}).Return(points, nil)
+
+	// Act
+	result, err := s.useCase.Series(s.ctx, websiteID, &from, nil)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), points, result)
+}
+
+func (s *CheckTestSuite) TestViewJQError() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:  websiteID,
+		URL: "https://example.com",
+		Setting: domain.Setting{
+			JQ: ".items.name",
+		},
+	}
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return([]byte(`{"items":[{"name":"a"}]}`), nil)
+
+	// Act
+	result, err := s.useCase.View(s.ctx, websiteID)
+
+	// Assert
+	assert.ErrorIs(s.T(), err, processors.ErrInvalidJQ)
+	assert.True(s.T(), domain.IsErrProcessing(err))
+	assert.Nil(s.T(), result)
+}
+
+func (s *CheckTestSuite) TestCheckProcessingFailed() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:  websiteID,
+		URL: "https://example.com",
+		Setting: domain.Setting{
+			Selectors: []string{".price"},
+		},
+	}
+	previousContent := []byte("10 USD")
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return([]byte(`<html><body><p>redesigned</p></body></html>`), nil)
+	s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(domain.Check{
+		Result: previousContent,
+		Status: domain.CheckStatusOK,
+	}, nil)
+	s.checkService.On("CreateCheck", s.ctx, mock.MatchedBy(func(check domain.Check) bool {
+		return check.WebsiteID == websiteID &&
+			check.HasError &&
+			!check.HasChanges &&
+			check.Status == domain.CheckStatusProcessingFailed &&
+			string(check.Result) == string(previousContent)
+	})).Return(domain.Check{}, nil)
+
+	// Act
+	result, err := s.useCase.Check(s.ctx, websiteID)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), result.HasChanges)
+	assert.ErrorIs(s.T(), result.ProcessingError, processors.ErrNoMatches)
+	s.checkService.AssertExpectations(s.T())
+	s.diffService.AssertNotCalled(s.T(), "Compare", mock.Anything, mock.Anything)
+}
+
+func (s *CheckTestSuite) TestCheckProcessingStillFailing() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:  websiteID,
+		URL: "https://example.com",
+		Setting: domain.Setting{
+			Selectors: []string{".price"},
+		},
+	}
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return([]byte(`<html><body><p>redesigned</p></body></html>`), nil)
+	s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(domain.Check{
+		Status: domain.CheckStatusProcessingFailed,
+	}, nil)
+
+	// Act
+	result, err := s.useCase.Check(s.ctx, websiteID)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), result)
+	s.checkService.AssertNotCalled(s.T(), "CreateCheck", mock.Anything, mock.Anything)
+}
+
+func (s *CheckTestSuite) TestCheckProcessingRecovered() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:  websiteID,
+		URL: "https://example.com",
+	}
+	content := []byte("10 USD")
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return(content, nil)
+	s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(domain.Check{
+		Result: content,
+		Status: domain.CheckStatusProcessingFailed,
+	}, nil)
+	s.diffService.On("Compare", content, content).Return(diff.Result{}, nil)
+	s.checkService.On("CreateCheck", s.ctx, mock.MatchedBy(func(check domain.Check) bool {
+		return !check.HasChanges && check.Status == domain.CheckStatusOK
+	})).Return(domain.Check{}, nil)
+
+	// Act
+	result, err := s.useCase.Check(s.ctx, websiteID)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), result)
+	s.checkService.AssertExpectations(s.T())
+}
+
+func (s *CheckTestSuite) TestViewCrawlProcessesEveryPage() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:   websiteID,
+		URL:  "https://example.com",
+		Mode: domain.ModeCrawl,
+		Setting: domain.Setting{
+			Selectors: []string{"h1"},
+		},
+	}
+	pages := []byte(`{"https://example.com/":"<h1>Home</h1><p>x</p>","https://example.com/a":"<h1>A</h1>"}`)
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return(pages, nil)
+
+	// Act
+	result, err := s.useCase.View(s.ctx, websiteID)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `{"https://example.com/":"Home","https://example.com/a":"A"}`, string(result))
+}
+
+func (s *CheckTestSuite) TestCheckCrawlComparesPages() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:   websiteID,
+		URL:  "https://example.com",
+		Mode: domain.ModeCrawl,
+	}
+	previousContent := []byte(`{"https://example.com/":"home"}`)
+	currentContent := []byte(`{"https://example.com/":"home v2"}`)
+	diffResult := diff.Result{HasChanges: true}
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return(currentContent, nil)
+	s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(domain.Check{Result: previousContent}, nil)
+	s.diffService.On("ComparePages", previousContent, currentContent).Return(diffResult, nil)
+	s.checkService.On("CreateCheck", s.ctx, mock.Anything).Return(domain.Check{}, nil)
+
+	// Act
+	result, err := s.useCase.Check(s.ctx, websiteID)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), result.HasChanges)
+	s.diffService.AssertExpectations(s.T())
+}
+
+func (s *CheckTestSuite) TestCheckCrawlCarriesOverFailedPage() {
+	// Arrange
+	websiteID := uuid.New()
+	website := domain.Website{
+		ID:   websiteID,
+		URL:  "https://example.com",
+		Mode: domain.ModeCrawl,
+	}
+	previousContent := []byte(`{"https://example.com/":"home","https://example.com/docs":"docs"}`)
+	currentContent, _ := json.Marshal(domain.PageSnapshot{
+		"https://example.com/":     "home v2",
+		"https://example.com/docs": domain.PageError(errors.New("timeout")),
+	})
+	carriedContent := []byte(`{"https://example.com/":"home v2","https://example.com/docs":"docs"}`)
+	diffResult := diff.Result{HasChanges: true}
+
+	s.websiteService.On("GetByID", s.ctx, websiteID).Return(website, nil)
+	s.httpService.On("Request", website).Return(currentContent, nil)
+	s.checkService.On("GetLatestCheckByWebsite", s.ctx, websiteID).Return(domain.Check{Result: previousContent}, nil)
+	s.diffService.On("ComparePages", previousContent, carriedContent).Return(diffResult, nil)
+	s.checkService.On("CreateCheck", s.ctx, mock.MatchedBy(func(check domain.Check) bool {
+		return string(check.Result) == string(carriedContent) &&
+			check.ErrorMessage == "failed pages: https://example.com/docs"
+	})).Return(domain.Check{}, nil)
+
+	// Act
+	result, err := s.useCase.Check(s.ctx, websiteID)
+
+	// Assert
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), result.HasChanges)
+	s.diffService.AssertExpectations(s.T())
+	s.checkService.AssertExpectations(s.T())
+}
+
 func (s *CheckTestSuite) TestViewWebsiteNotFound() {
 	// Arrange
 	websiteID := uuid.New()
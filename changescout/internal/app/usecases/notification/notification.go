@@ -8,6 +8,7 @@ import (
 	"github.com/gelleson/changescout/changescout/internal/pkg/templates"
 	"github.com/gelleson/changescout/changescout/pkg/clock"
 	"github.com/google/uuid"
+	"strconv"
 	"strings"
 	"text/template"
 )
@@ -18,6 +19,11 @@ type data struct {
 	URL         string
 	LastChecked string
 	Result      diff.Result
+	Triggered   []domain.Trigger
+	// Value and PreviousValue are the formatted numbers of the numeric setting, empty when it is disabled
+	Value         string
+	PreviousValue string
+	Error         string
 }
 
 //go:generate mockery --name Sender
@@ -67,17 +73,45 @@ func (c UseCase) NotifyChanges(ctx context.Context, siteID uuid.UUID, change dom
 		tmplString = *site.Setting.Template
 	}
 
-	tmpl, err := template.New("notification").Parse(tmplString)
+	return c.send(ctx, site, tmplString, data{
+		Name:          site.Name,
+		Mode:          site.Mode,
+		URL:           site.URL,
+		LastChecked:   c.now.Now().Format("2006-01-02 15:04:05"),
+		Result:        change.Check,
+		Triggered:     change.Triggered,
+		Value:         formatValue(change.Value),
+		PreviousValue: formatValue(change.PreviousValue),
+	})
+}
+
+// NotifyProcessingError alerts that the processors of the website started failing, e.g. a selector matches nothing.
+func (c UseCase) NotifyProcessingError(ctx context.Context, siteID uuid.UUID, processingErr error) error {
+	site, err := c.websiteService.GetByID(ctx, siteID)
 	if err != nil {
 		return err
 	}
 
-	data := data{
+	return c.send(ctx, site, templates.ProcessingErrorMessage, data{
 		Name:        site.Name,
 		Mode:        site.Mode,
 		URL:         site.URL,
 		LastChecked: c.now.Now().Format("2006-01-02 15:04:05"),
-		Result:      change.Check,
+		Error:       processingErr.Error(),
+	})
+}
+
+func formatValue(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+func (c UseCase) send(ctx context.Context, site domain.Website, tmplString string, data data) error {
+	tmpl, err := template.New("notification").Parse(tmplString)
+	if err != nil {
+		return err
 	}
 
 	var msg strings.Builder
@@ -86,7 +120,7 @@ func (c UseCase) NotifyChanges(ctx context.Context, siteID uuid.UUID, change dom
 	}
 
 	senders, _, err := c.notificationService.List(ctx, database.NotificationFilters{
-		WebsiteID: &siteID,
+		WebsiteID: &site.ID,
 		UserID:    &site.UserID,
 	}, domain.Pagination{})
 
@@ -2,13 +2,16 @@ package notification
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gelleson/changescout/changescout/internal/app/services/diff"
 	"github.com/gelleson/changescout/changescout/internal/app/usecases/notification/mocks"
 	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/utils/transform"
 	"github.com/gelleson/changescout/changescout/pkg/clock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -115,6 +118,69 @@ func (suite *NotificationTestSuite) TestNotifyChangesCustomTemplate() {
 	suite.mockSender.AssertExpectations(suite.T())
 }
 
+func (suite *NotificationTestSuite) TestNotifyChangesTriggered() {
+	siteID := uuid.New()
+	changeResult := domain.CheckResult{
+		Check: diff.Result{
+			Changes: []diff.Change{{Type: diff.Added, Content: "In stock"}},
+		},
+		Triggered:     []domain.Trigger{{Type: domain.TriggerAppears, Value: "In stock"}},
+		Value:         transform.ToPtr(19.5),
+		PreviousValue: transform.ToPtr(21.0),
+	}
+
+	site := domain.Website{
+		ID:     siteID,
+		Name:   "Example Site",
+		Mode:   "Live",
+		URL:    "http://example.com",
+		UserID: uuid.New(),
+	}
+
+	notifications := []domain.Notification{
+		{ID: uuid.New()},
+	}
+
+	suite.mockWebsiteService.On("GetByID", mock.Anything, siteID).Return(site, nil)
+	suite.mockNotificationService.On("List", mock.Anything, mock.Anything, domain.Pagination{}).Return(notifications, 1, nil)
+	suite.mockSender.On("Send", mock.MatchedBy(func(message string) bool {
+		return strings.Contains(message, "📈 21 → 19.5\n🔔 appears: In stock\n")
+	}), notifications[0]).Return(nil)
+
+	err := suite.useCase.NotifyChanges(context.Background(), siteID, changeResult)
+	suite.NoError(err)
+
+	suite.mockSender.AssertExpectations(suite.T())
+}
+
+func (suite *NotificationTestSuite) TestNotifyProcessingError() {
+	siteID := uuid.New()
+	site := domain.Website{
+		ID:     siteID,
+		Name:   "Example Site",
+		Mode:   "Live",
+		URL:    "http://example.com",
+		UserID: uuid.New(),
+	}
+
+	notifications := []domain.Notification{
+		{ID: uuid.New()},
+	}
+
+	suite.mockWebsiteService.On("GetByID", mock.Anything, siteID).Return(site, nil)
+	suite.mockNotificationService.On("List", mock.Anything, mock.Anything, domain.Pagination{}).Return(notifications, 1, nil)
+
+	expectedMessage := fmt.Sprintf("⚠️ %s (%s)\n🔗 %s | ⏱ %s \n\nProcessing failed: no matches: h1\n", site.Name, site.Mode, site.URL, suite.fixedTime.Format("2006-01-02 15:04:05"))
+	suite.mockSender.On("Send", expectedMessage, notifications[0]).Return(nil)
+
+	err := suite.useCase.NotifyProcessingError(context.Background(), siteID, errors.New("no matches: h1"))
+	suite.NoError(err)
+
+	suite.mockWebsiteService.AssertExpectations(suite.T())
+	suite.mockNotificationService.AssertExpectations(suite.T())
+	suite.mockSender.AssertExpectations(suite.T())
+}
+
 func TestNotificationTestSuite(t *testing.T) {
 	suite.Run(t, new(NotificationTestSuite))
 }
@@ -58,8 +58,16 @@ func (u WebsiteUseCase) GetByID(ctx context.Context, userId uuid.UUID, id uuid.U
 	if err != nil {
 		return domain.Website{}, err
 	}
+	site, err := u.websiteService.GetByID(ctx, id)
+	if err != nil {
+		return domain.Website{}, err
+	}
+	// The websites of other users are reported as missing, so their IDs can't be probed
+	if site.UserID != userId {
+		return domain.Website{}, domain.ErrWebsiteNotFound
+	}
 
-	return u.websiteService.GetByID(ctx, id)
+	return site, nil
 }
 
 func (u WebsiteUseCase) GetByURL(ctx context.Context, userId uuid.UUID, url string) (domain.Website, error) {
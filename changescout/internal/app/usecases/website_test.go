@@ -57,7 +57,7 @@ func (suite *WebsiteUseCaseTestSuite) TestCreate_UserNotFound() {
 func (suite *WebsiteUseCaseTestSuite) TestGetByID_Successful() {
 	userID := uuid.New()
 	websiteID := uuid.New()
-	expectedWebsite := domain.Website{URL: "http://example.com"}
+	expectedWebsite := domain.Website{URL: "http://example.com", UserID: userID}
 
 	suite.mockUserService.On("GetByID", suite.ctx, userID).Return(domain.User{}, nil).Once()
 	suite.mockWebsiteService.On("GetByID", suite.ctx, websiteID).Return(expectedWebsite, nil).Once()
@@ -68,6 +68,18 @@ func (suite *WebsiteUseCaseTestSuite) TestGetByID_Successful() {
 	assert.Equal(suite.T(), expectedWebsite, website)
 }
 
+func (suite *WebsiteUseCaseTestSuite) TestGetByID_NotOwner() {
+	userID := uuid.New()
+	websiteID := uuid.New()
+
+	suite.mockUserService.On("GetByID", suite.ctx, userID).Return(domain.User{}, nil).Once()
+	suite.mockWebsiteService.On("GetByID", suite.ctx, websiteID).Return(domain.Website{UserID: uuid.New()}, nil).Once()
+
+	_, err := suite.useCase.GetByID(suite.ctx, userID, websiteID)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrWebsiteNotFound)
+}
+
 func (suite *WebsiteUseCaseTestSuite) TestGetByID_UserNotFound() {
 	userID := uuid.New()
 	websiteID := uuid.New()
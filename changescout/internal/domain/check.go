@@ -6,16 +6,31 @@ import (
 	"time"
 )
 
+// CheckStatus tells whether a check produced content or at which stage it failed.
+type CheckStatus string
+
+const (
+	CheckStatusOK               CheckStatus = "ok"
+	CheckStatusRequestFailed    CheckStatus = "request_failed"
+	CheckStatusProcessingFailed CheckStatus = "processing_failed"
+)
+
 type Check struct {
 	ID           uuid.UUID    `json:"id"`
 	Cron         string       `json:"cron"`
 	WebsiteID    uuid.UUID    `json:"website_id"`
 	Result       []byte       `json:"result"`
 	HasError     bool         `json:"has_error"`
+	Status       CheckStatus  `json:"status"`
 	ErrorMessage string       `json:"error_message"`
 	HasChanges   bool         `json:"has_diff"`
 	DiffResult   *diff.Result `json:"diff_change"`
-	CreatedAt    time.Time    `json:"created_at"`
+	// Value is the number extracted by the numeric setting of the website.
+	Value *float64 `json:"value"`
+	// ExitCode and Stderr are captured for the exec mode checks.
+	ExitCode  *int      `json:"exit_code"`
+	Stderr    string    `json:"stderr"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type CheckResult struct {
@@ -23,4 +38,18 @@ type CheckResult struct {
 	NewValue   []byte
 	HasChanges bool
 	Check      diff.Result
+	// Triggered are the triggers of the website which fired for the change.
+	Triggered []Trigger
+	// Value and PreviousValue are the numbers extracted by the numeric setting of the website.
+	Value         *float64
+	PreviousValue *float64
+	// ProcessingError is set when the processors started failing with this check,
+	// e.g. a selector which suddenly matches zero elements.
+	ProcessingError error
+}
+
+// SeriesPoint is a value extracted by the numeric setting of a website at the time of the check.
+type SeriesPoint struct {
+	Value     float64   `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
 }
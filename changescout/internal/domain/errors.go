@@ -1,14 +1,51 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrCheckNotFound   = errors.New("check not found")
 	ErrDiffFailed      = errors.New("diff failed")
 	ErrRequestFailed   = errors.New("request failed")
 	ErrWebsiteNotFound = errors.New("website not found")
+	ErrModeDisabled    = errors.New("mode is disabled on the server")
 )
 
+// CommandError represents a failed command of the exec mode.
+type CommandError struct {
+	ExitCode int
+	Stderr   string
+	Err      error
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("command failed with exit code %d: %v", e.ExitCode, e.Err)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// ProcessingError represents a failure of the processing pipeline, e.g. a selector without matches.
+type ProcessingError struct {
+	Err error
+}
+
+func (e *ProcessingError) Error() string {
+	return fmt.Sprintf("processing failed: %v", e.Err)
+}
+
+func (e *ProcessingError) Unwrap() error {
+	return e.Err
+}
+
+func IsErrProcessing(err error) bool {
+	var processingErr *ProcessingError
+	return errors.As(err, &processingErr)
+}
+
 func IsErrCheckNotFound(err error) bool {
 	return errors.Is(err, ErrCheckNotFound)
 }
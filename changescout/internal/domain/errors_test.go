@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -42,3 +43,41 @@ func TestIsErrCheckNotFound(t *testing.T) {
 		})
 	}
 }
+
+func TestIsErrProcessing(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "ProcessingError",
+			err:      &ProcessingError{Err: errors.New("no matches")},
+			expected: true,
+		},
+		{
+			name:     "WrappedProcessingError",
+			err:      fmt.Errorf("view: %w", &ProcessingError{Err: errors.New("no matches")}),
+			expected: true,
+		},
+		{
+			name:     "DifferentError",
+			err:      errors.New("different error"),
+			expected: false,
+		},
+		{
+			name:     "NoError",
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := IsErrProcessing(tt.err)
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package domain
+
+import "time"
+
+type StepType string
+
+const (
+	StepRemove      StepType = "remove"
+	StepTable       StepType = "table"
+	StepHTML        StepType = "html"
+	StepConvert     StepType = "convert"
+	StepXPath       StepType = "xpath"
+	StepXML         StepType = "xml"
+	StepJSONPath    StepType = "json_path"
+	StepJQ          StepType = "jq"
+	StepCSV         StepType = "csv"
+	StepRegex       StepType = "regex"
+	StepFilter      StepType = "filter"
+	StepMask        StepType = "mask"
+	StepDeduplicate StepType = "deduplicate"
+	StepTrim        StepType = "trim"
+	StepSort        StepType = "sort"
+	StepScript      StepType = "script"
+	StepPlugin      StepType = "plugin"
+)
+
+// ProcessingStep is a step of the processing pipeline. Only the parameters of its type are used:
+//   - remove: Ignore.Selectors
+//   - mask: Ignore.Rules and Ignore.Presets
+//   - html: Selectors or Records
+//   - table, convert, xpath, xml, json_path, jq, csv, regex, filter, script, plugin: the option of the same name
+//   - deduplicate: Deduplication
+//   - sort: Sort
+//   - trim: no parameters
+type ProcessingStep struct {
+	Type          StepType            `json:"type"`
+	Ignore        IgnoreOption        `json:"ignore"`
+	Selectors     []string            `json:"selectors"`
+	Records       RecordOption        `json:"records"`
+	Table         TableOption         `json:"table"`
+	Convert       ConvertOption       `json:"convert"`
+	XPath         []string            `json:"xpath"`
+	XML           XMLOption           `json:"xml"`
+	JSONPath      []string            `json:"json_path"`
+	JQ            string              `json:"jq"`
+	CSV           CSVOption           `json:"csv"`
+	Regex         RegexOption         `json:"regex"`
+	Filter        FilterOption        `json:"filter"`
+	Script        string              `json:"script"`
+	Plugin        string              `json:"plugin"`
+	Deduplication DeduplicationOption `json:"deduplication"`
+	Sort          SortOption          `json:"sort"`
+}
+
+// Setting returns the processing settings of the step.
+func (s ProcessingStep) Setting() Setting {
+	setting := Setting{}
+
+	switch s.Type {
+	case StepRemove:
+		setting.Ignore.Selectors = s.Ignore.Selectors
+	case StepMask:
+		setting.Ignore.Rules = s.Ignore.Rules
+		setting.Ignore.Presets = s.Ignore.Presets
+	case StepHTML:
+		setting.Selectors = s.Selectors
+		setting.Records = s.Records
+	case StepTable:
+		setting.Table = s.Table
+	case StepConvert:
+		setting.Convert = s.Convert
+	case StepXPath:
+		setting.XPath = s.XPath
+	case StepXML:
+		setting.XML = s.XML
+	case StepJSONPath:
+		setting.JSONPath = s.JSONPath
+	case StepJQ:
+		setting.JQ = s.JQ
+	case StepCSV:
+		setting.CSV = s.CSV
+	case StepRegex:
+		setting.Regex = s.Regex
+	case StepFilter:
+		setting.Filter = s.Filter
+	case StepDeduplicate:
+		setting.Deduplication = true
+		setting.DeduplicationOption = s.Deduplication
+	case StepTrim:
+		setting.Trim = true
+	case StepSort:
+		setting.Sort = true
+		setting.SortOption = s.Sort
+	case StepScript:
+		setting.Script = s.Script
+	case StepPlugin:
+		setting.Plugin = s.Plugin
+	}
+
+	return setting
+}
+
+// ProcessingSteps returns the pipeline of the website.
+// Settings saved before the pipeline existed are converted from the per-processor options,
+// in the order they used to run.
+func (s Setting) ProcessingSteps() []ProcessingStep {
+	if len(s.Pipeline) > 0 {
+		return s.Pipeline
+	}
+
+	var steps []ProcessingStep
+	if len(s.Ignore.Selectors) > 0 {
+		steps = append(steps, ProcessingStep{Type: StepRemove, Ignore: IgnoreOption{Selectors: s.Ignore.Selectors}})
+	}
+	if s.Table.Selector != "" {
+		steps = append(steps, ProcessingStep{Type: StepTable, Table: s.Table})
+	}
+	if len(s.Selectors) > 0 || s.Records.RowSelector != "" {
+		steps = append(steps, ProcessingStep{Type: StepHTML, Selectors: s.Selectors, Records: s.Records})
+	}
+	if s.Convert.Format != "" {
+		steps = append(steps, ProcessingStep{Type: StepConvert, Convert: s.Convert})
+	}
+	if len(s.XPath) > 0 {
+		steps = append(steps, ProcessingStep{Type: StepXPath, XPath: s.XPath})
+	}
+	if !s.XML.IsZero() {
+		steps = append(steps, ProcessingStep{Type: StepXML, XML: s.XML})
+	}
+	if len(s.JSONPath) > 0 {
+		steps = append(steps, ProcessingStep{Type: StepJSONPath, JSONPath: s.JSONPath})
+	}
+	if s.JQ != "" {
+		steps = append(steps, ProcessingStep{Type: StepJQ, JQ: s.JQ})
+	}
+	if !s.CSV.IsZero() {
+		steps = append(steps, ProcessingStep{Type: StepCSV, CSV: s.CSV})
+	}
+	if len(s.Regex.Patterns) > 0 {
+		steps = append(steps, ProcessingStep{Type: StepRegex, Regex: s.Regex})
+	}
+	if !s.Filter.IsZero() {
+		steps = append(steps, ProcessingStep{Type: StepFilter, Filter: s.Filter})
+	}
+	if len(s.Ignore.Rules) > 0 || len(s.Ignore.Presets) > 0 {
+		steps = append(steps, ProcessingStep{Type: StepMask, Ignore: IgnoreOption{Rules: s.Ignore.Rules, Presets: s.Ignore.Presets}})
+	}
+	if s.Deduplication {
+		steps = append(steps, ProcessingStep{Type: StepDeduplicate, Deduplication: s.DeduplicationOption})
+	}
+	if s.Trim {
+		steps = append(steps, ProcessingStep{Type: StepTrim})
+	}
+	if s.Sort {
+		steps = append(steps, ProcessingStep{Type: StepSort, Sort: s.SortOption})
+	}
+	if s.Script != "" {
+		steps = append(steps, ProcessingStep{Type: StepScript, Script: s.Script})
+	}
+	if s.Plugin != "" {
+		steps = append(steps, ProcessingStep{Type: StepPlugin, Plugin: s.Plugin})
+	}
+
+	return steps
+}
+
+// PipelineTrace is the output of every processing stage of a website, used to debug the pipeline.
+type PipelineTrace struct {
+	// Raw is the fetched content before the processing.
+	Raw    []byte
+	Stages []StageTrace
+	// Result is the output of the last stage, empty when a stage failed.
+	Result []byte
+}
+
+type StageTrace struct {
+	Type    StepType
+	Output  []byte
+	Elapsed time.Duration
+	// Error is set for the failed stage, the following stages are not run.
+	Error string
+}
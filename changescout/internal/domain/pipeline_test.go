@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetting_ProcessingSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		setting  Setting
+		expected []ProcessingStep
+	}{
+		{
+			name:     "Empty",
+			setting:  Setting{},
+			expected: nil,
+		},
+		{
+			name:     "Empty selectors",
+			setting:  Setting{Selectors: []string{}},
+			expected: nil,
+		},
+		{
+			name: "Legacy options",
+			setting: Setting{
+				Selectors:     []string{"h1"},
+				JSONPath:      []string{"$.name"},
+				Deduplication: true,
+				Trim:          true,
+				Sort:          true,
+			},
+			expected: []ProcessingStep{
+				{Type: StepHTML, Selectors: []string{"h1"}},
+				{Type: StepJSONPath, JSONPath: []string{"$.name"}},
+				{Type: StepDeduplicate},
+				{Type: StepTrim},
+				{Type: StepSort},
+			},
+		},
+		{
+			name: "Legacy ignore options",
+			setting: Setting{
+				Ignore: IgnoreOption{
+					Selectors: []string{".ad"},
+					Presets:   []IgnorePreset{IgnorePresetUUIDs},
+				},
+			},
+			expected: []ProcessingStep{
+				{Type: StepRemove, Ignore: IgnoreOption{Selectors: []string{".ad"}}},
+				{Type: StepMask, Ignore: IgnoreOption{Presets: []IgnorePreset{IgnorePresetUUIDs}}},
+			},
+		},
+		{
+			name: "Legacy filter after extraction",
+			setting: Setting{
+				Filter:   FilterOption{Exclude: []string{"Advertisement"}},
+				Regex:    RegexOption{Patterns: []string{`\d+`}},
+				JSONPath: []string{"$.items"},
+				Trim:     true,
+			},
+			expected: []ProcessingStep{
+				{Type: StepJSONPath, JSONPath: []string{"$.items"}},
+				{Type: StepRegex, Regex: RegexOption{Patterns: []string{`\d+`}}},
+				{Type: StepFilter, Filter: FilterOption{Exclude: []string{"Advertisement"}}},
+				{Type: StepTrim},
+			},
+		},
+		{
+			name: "Legacy deduplication and sort options",
+			setting: Setting{
+				Deduplication:       true,
+				DeduplicationOption: DeduplicationOption{Mode: DeduplicationModeSentence},
+				Sort:                true,
+				SortOption:          SortOption{Mode: SortModeNatural, Reverse: true},
+			},
+			expected: []ProcessingStep{
+				{Type: StepDeduplicate, Deduplication: DeduplicationOption{Mode: DeduplicationModeSentence}},
+				{Type: StepSort, Sort: SortOption{Mode: SortModeNatural, Reverse: true}},
+			},
+		},
+		{
+			name: "Pipeline takes precedence",
+			setting: Setting{
+				Selectors: []string{"h1"},
+				Pipeline:  []ProcessingStep{{Type: StepSort}, {Type: StepDeduplicate}},
+			},
+			expected: []ProcessingStep{{Type: StepSort}, {Type: StepDeduplicate}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if steps := tt.setting.ProcessingSteps(); !reflect.DeepEqual(steps, tt.expected) {
+				t.Errorf("ProcessingSteps() = %+v, expected %+v", steps, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProcessingStep_Setting(t *testing.T) {
+	step := ProcessingStep{
+		Type:      StepMask,
+		Selectors: []string{"h1"},
+		Ignore: IgnoreOption{
+			Selectors: []string{".ad"},
+			Rules:     []IgnoreRule{{Pattern: `\d+`}},
+		},
+	}
+
+	setting := step.Setting()
+
+	if setting.Selectors != nil || setting.Ignore.Selectors != nil {
+		t.Errorf("Setting() kept the parameters of other step types: %+v", setting)
+	}
+	if len(setting.Ignore.Rules) != 1 {
+		t.Errorf("Setting() = %+v, expected the mask rules", setting)
+	}
+	if !(ProcessingStep{Type: StepSort}).Setting().Sort {
+		t.Errorf("Setting() of the sort step should enable sorting")
+	}
+	if sort := (ProcessingStep{Type: StepSort, Sort: SortOption{Mode: SortModeNumeric}}).Setting(); sort.SortOption.Mode != SortModeNumeric {
+		t.Errorf("Setting() = %+v, expected the sort option", sort)
+	}
+}
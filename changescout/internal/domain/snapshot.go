@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// PageSnapshot maps a page URL to its content. It is the result of the crawl mode.
+type PageSnapshot map[string]string
+
+// pageErrorPrefix starts the content of a page which failed to fetch, a text page never starts with a NUL byte.
+const pageErrorPrefix = "\x00page error: "
+
+// PageError is the content of a page which failed to fetch.
+func PageError(err error) string {
+	return pageErrorPrefix + err.Error()
+}
+
+// IsPageError reports whether the content is the one of a page which failed to fetch.
+func IsPageError(content string) bool {
+	return strings.HasPrefix(content, pageErrorPrefix)
+}
+
+// CarryOver replaces the pages which failed to fetch with their content in the previous snapshot,
+// so a transient error is not reported as a removed page. The failed pages unknown to the previous
+// snapshot are dropped. It returns the URLs of the failed pages.
+func (s PageSnapshot) CarryOver(previous PageSnapshot) []string {
+	var failed []string
+	for url, content := range s {
+		if !IsPageError(content) {
+			continue
+		}
+		failed = append(failed, url)
+
+		if prev, ok := previous[url]; ok && !IsPageError(prev) {
+			s[url] = prev
+		} else {
+			delete(s, url)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
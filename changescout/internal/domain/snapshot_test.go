@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageSnapshot_CarryOver(t *testing.T) {
+	failure := PageError(errors.New("timeout"))
+	previous := PageSnapshot{
+		"https://example.com/":     "Home",
+		"https://example.com/docs": "Docs",
+		"https://example.com/old":  failure,
+	}
+	current := PageSnapshot{
+		"https://example.com/":     "Home v2",
+		"https://example.com/docs": failure,
+		"https://example.com/old":  failure,
+		"https://example.com/new":  failure,
+	}
+
+	failed := current.CarryOver(previous)
+
+	assert.Equal(t, []string{
+		"https://example.com/docs",
+		"https://example.com/new",
+		"https://example.com/old",
+	}, failed)
+	assert.Equal(t, PageSnapshot{
+		"https://example.com/":     "Home v2",
+		"https://example.com/docs": "Docs",
+	}, current)
+}
+
+func TestIsPageError(t *testing.T) {
+	assert.True(t, IsPageError(PageError(errors.New("not found"))))
+	assert.False(t, IsPageError("page error: not found"))
+}
@@ -1,10 +1,14 @@
 package domain
 
 import (
+	"github.com/gelleson/changescout/changescout/internal/app/services/diff"
 	"github.com/gelleson/changescout/changescout/pkg/crons"
 	"github.com/google/uuid"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type Mode string
@@ -12,6 +16,9 @@ type Mode string
 const (
 	ModePlain    Mode = "plain"
 	ModeRenderer Mode = "renderer"
+	ModeExec     Mode = "exec"
+	ModeCrawl    Mode = "crawl"
+	ModeWorkflow Mode = "workflow"
 )
 
 // Setting represents the options for a website. It can be used to configure the HTTP request, extract text from the response, and handle errors.
@@ -20,21 +27,78 @@ type Setting struct {
 	UserAgent string      `json:"user_agent"`
 	Referer   string      `json:"referer"`
 	Method    string      `json:"method"`
+	// Body is sent with the request, e.g. for POST APIs
+	Body string `json:"body"`
 	// Template is a Go template to render notifications
 	Template *string `json:"template"`
 	// RenderedOption is setting for the rendered mode
 	RenderedOption RenderedOption `json:"rendered_option"`
+	// TLS is setting for the TLS connection of the plain mode
+	TLS TLSOption `json:"tls"`
+	// Exec is setting for the exec mode
+	Exec ExecOption `json:"exec"`
+	// Crawl is setting for the crawl mode
+	Crawl CrawlOption `json:"crawl"`
+	// Pagination is setting to fetch every page of a paginated listing
+	Pagination PaginationOption `json:"pagination"`
+	// Workflow is an ordered list of requests for the workflow mode
+	Workflow []WorkflowStep `json:"workflow"`
 
-	// Selectors is a list of CSS selectors to extract text from the HTML content or xpath expressions to extract text from the XML content.
+	// Pipeline is the ordered list of processing steps applied to the content.
+	// The options below are kept for settings saved before the pipeline, see ProcessingSteps.
+	Pipeline []ProcessingStep `json:"pipeline"`
+
+	// Ignore is setting to mask volatile content, so it is not reported as a change
+	Ignore IgnoreOption `json:"ignore"`
+	// Selectors is a list of CSS selectors to extract text from the HTML content.
+	// A selector ending with ::attr(name) extracts the attribute value instead, e.g. a.product::attr(href).
 	Selectors []string `json:"selectors"`
+	// Records is setting to extract rows of named fields from the HTML content, it takes precedence over Selectors.
+	Records RecordOption `json:"records"`
+	// Table is setting to extract the rows of an HTML table
+	Table TableOption `json:"table"`
+	// Convert is setting to turn the HTML into readable Markdown or plain text
+	Convert ConvertOption `json:"convert"`
+	// XPath is a list of XPath expressions to extract elements, attributes or text from the HTML or XML content.
+	XPath []string `json:"xpath"`
+	// Regex is setting to extract text with regular expressions
+	Regex RegexOption `json:"regex"`
+	// Filter is setting to keep or drop lines by keywords or regular expressions
+	Filter FilterOption `json:"filter"`
 	// Deduplication is a boolean flag to enable or disable deduplication of websites.
 	Deduplication bool `json:"deduplication"`
-	// Sort alphabetically
+	// DeduplicationOption configures how the content is split and compared for the deduplication.
+	DeduplicationOption DeduplicationOption `json:"deduplication_option"`
+	// Sort the lines, alphabetically unless SortOption says otherwise
 	Sort bool `json:"sort"`
+	// SortOption configures the order of the sorted lines.
+	SortOption SortOption `json:"sort_option"`
 	// Trim whitespace
 	Trim bool `json:"trim"`
+	// XML is setting to query and pretty-print XML documents
+	XML XMLOption `json:"xml"`
+	// CSV is setting to select columns and rows of CSV documents
+	CSV CSVOption `json:"csv"`
 	// JSONPath is a list of JSONPath expressions to extract text from the JSON content.
 	JSONPath []string `json:"json_path"`
+	// JQ is a jq expression to filter and reshape the JSON content.
+	JQ string `json:"jq"`
+	// Script is a Starlark program defining process(body), which returns the new content
+	// and optionally whether the change is significant, e.g. `return body, True`.
+	Script string `json:"script"`
+	// Plugin is the name of a WebAssembly plugin loaded from the plugins directory of the server.
+	Plugin string `json:"plugin"`
+
+	// Diff is setting of the comparison of the content with the previous check
+	Diff DiffOption `json:"diff"`
+	// Threshold is setting to skip the notifications of trivial changes, the checks are still recorded.
+	Threshold ThresholdOption `json:"threshold"`
+	// Numeric is setting to extract a number from the processed content, e.g. a price, and alert on its value.
+	Numeric NumericOption `json:"numeric"`
+
+	// Triggers are evaluated against the processed content, a change is notified only when one of them
+	// or a condition of the Numeric setting fires. Every change is notified when there are neither.
+	Triggers []Trigger `json:"triggers"`
 }
 
 // Website represents a website to be monitored.
@@ -60,3 +124,402 @@ type RenderedOption struct {
 	// This is beneficial to set a time frame after which the wait will timeout if the selector doesn’t appear.
 	WaitForTimeout *int `json:"wait_for_timeout"`
 }
+
+// TLSOption represents TLS settings used to connect to the website.
+// The zero value means the default client with the system certificate pool is used.
+type TLSOption struct {
+	// CACertificates is a list of PEM encoded CA certificates trusted in addition to the system pool.
+	CACertificates []string `json:"ca_certificates"`
+	// ClientCertificate is a PEM encoded certificate presented to the server for mutual TLS.
+	ClientCertificate string `json:"client_certificate"`
+	// ClientKey is a PEM encoded private key of the ClientCertificate.
+	ClientKey string `json:"client_key"`
+	// MinVersion is the minimum accepted TLS version, one of "1.0", "1.1", "1.2" or "1.3".
+	MinVersion string `json:"min_version"`
+	// InsecureSkipVerify disables verification of the server certificate chain and host name.
+	// It should only be used for testing, the UI shows a warning when it is enabled.
+	InsecureSkipVerify bool `json:"insecure_skip_verify"`
+}
+
+// IsZero reports whether no TLS settings are configured.
+func (o TLSOption) IsZero() bool {
+	return len(o.CACertificates) == 0 &&
+		o.ClientCertificate == "" &&
+		o.ClientKey == "" &&
+		o.MinVersion == "" &&
+		!o.InsecureSkipVerify
+}
+
+// ExecOption represents settings for the exec mode.
+// The command output on stdout is used as the website content.
+type ExecOption struct {
+	// Command is the executable to run. It must be allow-listed on the server.
+	Command string `json:"command"`
+	// Args are passed to the command as is, without a shell.
+	Args []string `json:"args"`
+	// Env is a list of additional environment variables in the KEY=VALUE form.
+	// Only the variables allow-listed on the server can be set.
+	Env []string `json:"env"`
+	// Dir is the working directory of the command.
+	Dir string `json:"dir"`
+	// Timeout specifies how long the command may run (in seconds).
+	Timeout *int `json:"timeout"`
+}
+
+// CommandOutput represents the output of a successful command of the exec mode.
+type CommandOutput struct {
+	Stdout []byte
+	Stderr string
+}
+
+// CrawlOption represents settings for the crawl mode.
+// Crawling starts from the website URL and follows links within the same origin.
+type CrawlOption struct {
+	// MaxDepth is how many links away from the website URL pages are crawled, 0 crawls the website URL only.
+	// It defaults to 1 when not set.
+	MaxDepth *int `json:"max_depth"`
+	// MaxPages limits the number of crawled pages.
+	MaxPages int `json:"max_pages"`
+	// Include is a list of regular expressions, when set only matching URLs are crawled.
+	Include []string `json:"include"`
+	// Exclude is a list of regular expressions, matching URLs are never crawled.
+	Exclude []string `json:"exclude"`
+}
+
+// PaginationOption represents settings to fetch every page of a paginated listing or API.
+// Only one of NextSelector, NextJSONPath or PageParam is expected to be set.
+// The fetched JSON pages are merged into a JSON array of the pages, e.g. [page1, page2], even a single page,
+// so the JSONPath and jq settings have to select from the array.
+type PaginationOption struct {
+	// NextSelector is a CSS selector of the link to the next page, its href attribute is followed.
+	NextSelector string `json:"next_selector"`
+	// NextJSONPath is a JSONPath expression to the next page URL or cursor.
+	NextJSONPath string `json:"next_json_path"`
+	// CursorParam is the query parameter the value of NextJSONPath is passed in.
+	// When empty the value of NextJSONPath is used as the next page URL.
+	CursorParam string `json:"cursor_param"`
+	// PageParam is the query parameter with the page number.
+	// It is incremented until an empty page, or a page without new items when ItemSelector is set.
+	PageParam string `json:"page_param"`
+	// StartPage is the number of the first page for PageParam, 1 by default.
+	StartPage *int `json:"start_page"`
+	// MaxPages limits the number of fetched pages.
+	MaxPages int `json:"max_pages"`
+	// ItemSelector is a CSS selector of the listing items. A page without items not seen on the previous pages,
+	// e.g. a "no results" page, ends the pagination.
+	ItemSelector string `json:"item_selector"`
+}
+
+// IsZero reports whether pagination is disabled.
+func (o PaginationOption) IsZero() bool {
+	return o.NextSelector == "" && o.NextJSONPath == "" && o.PageParam == ""
+}
+
+// WorkflowStep represents a single request of the workflow mode.
+// URL, header values and body are Go templates rendered with variables extracted by the previous steps,
+// e.g. {{ .token }}. The response of the last step is used as the website content.
+type WorkflowStep struct {
+	Name   string `json:"name"`
+	Method string `json:"method"`
+	// URL of the step, the website URL is used when empty.
+	URL     string           `json:"url"`
+	Headers []WorkflowHeader `json:"headers"`
+	Body    string           `json:"body"`
+	// Variables are extracted from the step response and available to the next steps.
+	Variables []WorkflowVariable `json:"variables"`
+}
+
+// WorkflowHeader is a request header of a workflow step.
+type WorkflowHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// WorkflowVariable describes how to extract a variable from a workflow step response.
+// Only one of JSONPath or Regex is expected to be set.
+type WorkflowVariable struct {
+	Name string `json:"name"`
+	// JSONPath is a JSONPath expression, non-string values are stored as JSON.
+	JSONPath string `json:"json_path"`
+	// Regex is a regular expression, the first capture group or the whole match is stored.
+	Regex string `json:"regex"`
+}
+
+type RegexMode string
+
+const (
+	// RegexModeFirst keeps the first match of every pattern.
+	RegexModeFirst RegexMode = "first"
+	// RegexModeAll keeps every match of every pattern.
+	RegexModeAll RegexMode = "all"
+	// RegexModeGroups renders the named groups of every match as a JSON array of objects.
+	RegexModeGroups RegexMode = "groups"
+)
+
+// RegexOption represents settings to extract text with regular expressions.
+type RegexOption struct {
+	Patterns []string `json:"patterns"`
+	// Mode defaults to RegexModeFirst.
+	Mode RegexMode `json:"mode"`
+}
+
+type TriggerType string
+
+const (
+	// TriggerContains fires when the content contains the value.
+	TriggerContains TriggerType = "contains"
+	// TriggerNotContains fires when the content doesn't contain the value.
+	TriggerNotContains TriggerType = "not_contains"
+	// TriggerAppears fires when the value is in the content, but wasn't in the previous one.
+	TriggerAppears TriggerType = "appears"
+	// TriggerDisappears fires when the value was in the previous content, but isn't anymore.
+	TriggerDisappears TriggerType = "disappears"
+	// TriggerRegex fires when the content matches the regular expression of the value.
+	TriggerRegex TriggerType = "regex"
+)
+
+// Trigger is a rule deciding whether a change of the content is worth a notification,
+// e.g. "In stock" appears or "Sold out" disappears.
+type Trigger struct {
+	Type       TriggerType `json:"type"`
+	Value      string      `json:"value"`
+	IgnoreCase bool        `json:"ignore_case"`
+}
+
+// DiffOption represents settings of the comparison, the JSON content is compared structurally.
+type DiffOption struct {
+	// JSONArrayKey is the field matching the objects of the JSON arrays, e.g. "id",
+	// so the reordered elements are not reported. The elements are matched by position when empty.
+	JSONArrayKey string `json:"json_array_key"`
+}
+
+// ThresholdOption represents the minimum change worth a notification, all the set minimums have to be reached.
+type ThresholdOption struct {
+	// MinChangePercent is compared with the change percent of the diff.
+	MinChangePercent float64 `json:"min_change_percent"`
+	MinChangedChars  int     `json:"min_changed_chars"`
+	MinChangedLines  int     `json:"min_changed_lines"`
+	// ChangeTypes limits the counted changes to the types, e.g. only the additions. All the types count when empty.
+	ChangeTypes []diff.ChangeType `json:"change_types"`
+}
+
+// Exceeded reports whether the change reaches the minimums of the threshold.
+func (o ThresholdOption) Exceeded(result diff.Result) bool {
+	if result.ChangePercent < o.MinChangePercent {
+		return false
+	}
+
+	var changes, chars, lines int
+	for _, change := range result.Changes {
+		if len(o.ChangeTypes) > 0 && !slices.Contains(o.ChangeTypes, change.Type) {
+			continue
+		}
+		changes++
+		chars += utf8.RuneCountInString(change.Content)
+		lines += strings.Count(strings.TrimSuffix(change.Content, "\n"), "\n") + 1
+	}
+
+	if len(o.ChangeTypes) > 0 && changes == 0 {
+		return false
+	}
+	return chars >= o.MinChangedChars && lines >= o.MinChangedLines
+}
+
+// NumericOption represents settings to extract a number from the processed content.
+// The number is taken from the first match of Selector, JSONPath or Regex, or from the whole content
+// when none is set. Every extracted value is stored, so the series can be charted.
+type NumericOption struct {
+	Enabled  bool   `json:"enabled"`
+	Selector string `json:"selector"`
+	JSONPath string `json:"json_path"`
+	Regex    string `json:"regex"`
+	// Locale decides the decimal and the group separators, e.g. "de" parses "1.234,5" as 1234.5.
+	// It defaults to "en".
+	Locale string `json:"locale"`
+	// Above and Below fire when the value crosses the threshold.
+	Above *float64 `json:"above"`
+	Below *float64 `json:"below"`
+	// DeltaPercent fires when the value moves by more than the percentage from the previous value.
+	DeltaPercent *float64 `json:"delta_percent"`
+}
+
+// HasConditions reports whether the changes of the value are notified only when a condition fires.
+func (o NumericOption) HasConditions() bool {
+	return o.Enabled && (o.Above != nil || o.Below != nil || o.DeltaPercent != nil)
+}
+
+// FilterOption represents settings to keep or drop lines of the content.
+// A line is kept when it matches any of the Include patterns, or Include is empty,
+// and none of the Exclude patterns.
+type FilterOption struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+	// Regex makes the patterns regular expressions instead of plain keywords.
+	Regex      bool `json:"regex"`
+	IgnoreCase bool `json:"ignore_case"`
+}
+
+// IsZero reports whether the line filter is disabled.
+func (o FilterOption) IsZero() bool {
+	return len(o.Include) == 0 && len(o.Exclude) == 0
+}
+
+type DeduplicationMode string
+
+const (
+	// DeduplicationModeLine removes the repeated lines.
+	DeduplicationModeLine DeduplicationMode = "line"
+	// DeduplicationModeSentence removes the repeated sentences, which end with ".", "!" or "?".
+	DeduplicationModeSentence DeduplicationMode = "sentence"
+	// DeduplicationModeDelimiter removes the repeated tokens separated by the Delimiter.
+	DeduplicationModeDelimiter DeduplicationMode = "delimiter"
+)
+
+// DeduplicationOption represents settings to remove the repeated parts of the content.
+// The first occurrence of every part is kept as is, the normalisation only applies to the comparison.
+type DeduplicationOption struct {
+	// Mode defaults to DeduplicationModeLine.
+	Mode                DeduplicationMode `json:"mode"`
+	Delimiter           string            `json:"delimiter"`
+	IgnoreCase          bool              `json:"ignore_case"`
+	NormalizeWhitespace bool              `json:"normalize_whitespace"`
+}
+
+type SortMode string
+
+const (
+	// SortModeLexical compares the lines byte by byte.
+	SortModeLexical SortMode = "lexical"
+	// SortModeNumeric compares the first number of the lines, the lines without numbers go last.
+	SortModeNumeric SortMode = "numeric"
+	// SortModeNatural compares the digit runs by their value, e.g. "item2" goes before "item10".
+	SortModeNatural SortMode = "natural"
+)
+
+// SortOption represents settings to order the lines.
+type SortOption struct {
+	// Mode defaults to SortModeLexical.
+	Mode    SortMode `json:"mode"`
+	Reverse bool     `json:"reverse"`
+	// KeyPattern is a regular expression selecting the sort key of a line, the first group when it has one.
+	// The whole line is the key when the pattern doesn't match.
+	KeyPattern string `json:"key_pattern"`
+}
+
+type IgnorePreset string
+
+const (
+	IgnorePresetISODates      IgnorePreset = "iso_dates"
+	IgnorePresetUUIDs         IgnorePreset = "uuids"
+	IgnorePresetHexHashes     IgnorePreset = "hex_hashes"
+	IgnorePresetRelativeTimes IgnorePreset = "relative_times"
+)
+
+// IgnoreOption represents settings to mask volatile content like timestamps, tokens or counters.
+type IgnoreOption struct {
+	// Selectors is a list of CSS selectors of the elements removed from the HTML before extraction.
+	Selectors []string `json:"selectors"`
+	// Rules are regex replacements applied to the extracted content.
+	Rules []IgnoreRule `json:"rules"`
+	// Presets are built-in rules for common volatile values.
+	Presets []IgnorePreset `json:"presets"`
+}
+
+// IgnoreRule replaces every match of the pattern with the replacement, which may reference groups like $1.
+type IgnoreRule struct {
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement"`
+}
+
+// RecordOption represents settings to extract a JSON array of objects, one per row of a listing.
+type RecordOption struct {
+	// RowSelector is a CSS selector of the rows.
+	RowSelector string `json:"row_selector"`
+	// Fields are looked up inside every row.
+	Fields []RecordField `json:"fields"`
+}
+
+// RecordField is a named field of a record.
+// Selector supports the ::attr(name) syntax, an empty selector refers to the row itself.
+type RecordField struct {
+	Name     string `json:"name"`
+	Selector string `json:"selector"`
+}
+
+type ConvertFormat string
+
+const (
+	ConvertFormatMarkdown ConvertFormat = "markdown"
+	ConvertFormatText     ConvertFormat = "text"
+)
+
+// ConvertOption represents settings to convert the HTML into a human-readable format.
+// When set, the HTML selectors keep the matched elements instead of their text, so they can be converted.
+type ConvertOption struct {
+	// Format is empty when the conversion is disabled.
+	Format ConvertFormat `json:"format"`
+	// Readability isolates the main content of the page before the conversion.
+	Readability bool `json:"readability"`
+}
+
+type TableFormat string
+
+const (
+	TableFormatJSON TableFormat = "json"
+	TableFormatCSV  TableFormat = "csv"
+)
+
+// TableOption represents settings to extract an HTML table as rows keyed by its header.
+type TableOption struct {
+	// Selector is a CSS selector of the table, the first match is used.
+	Selector string `json:"selector"`
+	// Format defaults to TableFormatJSON.
+	Format TableFormat `json:"format"`
+	// KeyColumns are header names the rows are sorted by, so reordering is not reported as a change.
+	KeyColumns []string `json:"key_columns"`
+}
+
+// XMLOption represents settings to extract and canonically pretty-print XML documents.
+type XMLOption struct {
+	// Enabled pretty-prints the whole document when no XPath expression is set.
+	Enabled bool `json:"enabled"`
+	// XPath is a list of XPath expressions, prefixes are resolved with Namespaces.
+	XPath []string `json:"xpath"`
+	// Namespaces maps the prefixes used in XPath to namespace URIs.
+	Namespaces []XMLNamespace `json:"namespaces"`
+}
+
+// IsZero reports whether the XML processing is disabled.
+func (o XMLOption) IsZero() bool {
+	return !o.Enabled && len(o.XPath) == 0
+}
+
+type XMLNamespace struct {
+	Prefix string `json:"prefix"`
+	URI    string `json:"uri"`
+}
+
+// CSVOption represents settings to select columns and rows of CSV documents.
+type CSVOption struct {
+	// Enabled normalises the document when no other option is set.
+	Enabled bool `json:"enabled"`
+	// Delimiter defaults to a comma.
+	Delimiter string `json:"delimiter"`
+	// Columns are header names kept in the output, all columns are kept when empty.
+	Columns []string `json:"columns"`
+	// Filters keep only the rows matching every filter.
+	Filters []CSVFilter `json:"filters"`
+	// KeyColumns are header names the rows are sorted by.
+	KeyColumns []string `json:"key_columns"`
+}
+
+// IsZero reports whether the CSV processing is disabled.
+func (o CSVOption) IsZero() bool {
+	return !o.Enabled && len(o.Columns) == 0 && len(o.Filters) == 0 && len(o.KeyColumns) == 0
+}
+
+// CSVFilter keeps the rows where the column value matches the regex pattern.
+type CSVFilter struct {
+	Column  string `json:"column"`
+	Pattern string `json:"pattern"`
+}
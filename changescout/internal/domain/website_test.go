@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/app/services/diff"
+)
+
+func TestThresholdOption_Exceeded(t *testing.T) {
+	result := diff.Result{
+		HasChanges:    true,
+		ChangePercent: 12.5,
+		Changes: []diff.Change{
+			{Type: diff.Added, Content: "new line\nanother line\n"},
+			{Type: diff.Removed, Content: "old"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		threshold ThresholdOption
+		expected  bool
+	}{
+		{name: "No threshold", threshold: ThresholdOption{}, expected: true},
+		{name: "Change percent reached", threshold: ThresholdOption{MinChangePercent: 10}, expected: true},
+		{name: "Change percent not reached", threshold: ThresholdOption{MinChangePercent: 20}, expected: false},
+		{name: "Changed chars reached", threshold: ThresholdOption{MinChangedChars: 25}, expected: true},
+		{name: "Changed chars not reached", threshold: ThresholdOption{MinChangedChars: 26}, expected: false},
+		{name: "Changed lines reached", threshold: ThresholdOption{MinChangedLines: 3}, expected: true},
+		{name: "Changed lines not reached", threshold: ThresholdOption{MinChangedLines: 4}, expected: false},
+		{name: "Only removals", threshold: ThresholdOption{ChangeTypes: []diff.ChangeType{diff.Removed}}, expected: true},
+		{name: "Only removed lines", threshold: ThresholdOption{ChangeTypes: []diff.ChangeType{diff.Removed}, MinChangedLines: 2}, expected: false},
+		{name: "Only modifications", threshold: ThresholdOption{ChangeTypes: []diff.ChangeType{diff.Modified}}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if exceeded := tt.threshold.Exceeded(result); exceeded != tt.expected {
+				t.Errorf("Exceeded() = %v, expected %v", exceeded, tt.expected)
+			}
+		})
+	}
+}
+
+func TestThresholdOption_ExceededMultiLineEdit(t *testing.T) {
+	// Three added lines are three changed lines of two chars each, whatever the diff segments are
+	result, err := diff.NewDiffService().Compare([]byte("a\n"), []byte("a\nx1\nx2\nx3\n"))
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		threshold ThresholdOption
+		expected  bool
+	}{
+		{name: "Changed lines reached", threshold: ThresholdOption{MinChangedLines: 3}, expected: true},
+		{name: "Changed lines not reached", threshold: ThresholdOption{MinChangedLines: 4}, expected: false},
+		{name: "Changed chars reached", threshold: ThresholdOption{MinChangedChars: 6}, expected: true},
+		{name: "Changed chars not reached", threshold: ThresholdOption{MinChangedChars: 7}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if exceeded := tt.threshold.Exceeded(result); exceeded != tt.expected {
+				t.Errorf("Exceeded() = %v, expected %v", exceeded, tt.expected)
+			}
+		})
+	}
+}
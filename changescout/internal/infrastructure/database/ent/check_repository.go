@@ -25,11 +25,15 @@ func (r *CheckRepository) CreateCheck(ctx context.Context, check domain.Check) (
 		SetWebsiteID(check.WebsiteID).
 		SetResult(check.Result).
 		SetHasError(check.HasError).
+		SetStatus(string(check.Status)).
 		SetErrorMessage(check.ErrorMessage).
 		SetHasDiff(check.HasChanges).
 		SetDiffChange(check.DiffResult).
 		SetCreatedAt(time.Now()).
 		SetDiffChange(check.DiffResult).
+		SetNillableValue(check.Value).
+		SetNillableExitCode(check.ExitCode).
+		SetStderr(check.Stderr).
 		Save(ctx)
 	if err != nil {
 		return domain.Check{}, err
@@ -123,6 +127,39 @@ func (r *CheckRepository) GetLatestCheckByWebsite(ctx context.Context, websiteID
 	return mapCheckToEntity(found), nil
 }
 
+// GetSeries returns the values extracted by the checks of the website in chronological order.
+func (r *CheckRepository) GetSeries(ctx context.Context, filters database.CheckFilters) ([]domain.SeriesPoint, error) {
+	query := r.client.Check.Query().
+		Where(check.ValueNotNil())
+
+	if filters.WebsiteID != nil {
+		query = query.Where(check.WebsiteID(*filters.WebsiteID))
+	}
+	if filters.FromDate != nil && !filters.FromDate.IsZero() {
+		query = query.Where(check.CreatedAtGTE(*filters.FromDate))
+	}
+	if filters.ToDate != nil && !filters.ToDate.IsZero() {
+		query = query.Where(check.CreatedAtLTE(*filters.ToDate))
+	}
+
+	checks, err := query.
+		Order(ent.Asc(check.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]domain.SeriesPoint, 0, len(checks))
+	for _, c := range checks {
+		points = append(points, domain.SeriesPoint{
+			Value:     *c.Value,
+			CreatedAt: c.CreatedAt,
+		})
+	}
+
+	return points, nil
+}
+
 // Helper function to map ent.Check to domain.Check
 func mapCheckToEntity(check *ent.Check) domain.Check {
 	return domain.Check{
@@ -130,9 +167,13 @@ func mapCheckToEntity(check *ent.Check) domain.Check {
 		WebsiteID:    check.WebsiteID,
 		DiffResult:   check.DiffChange,
 		HasError:     check.HasError,
+		Status:       domain.CheckStatus(check.Status),
 		ErrorMessage: check.ErrorMessage,
 		HasChanges:   check.HasDiff,
 		Result:       check.Result,
+		Value:        check.Value,
+		ExitCode:     check.ExitCode,
+		Stderr:       check.Stderr,
 		CreatedAt:    check.CreatedAt,
 	}
 }
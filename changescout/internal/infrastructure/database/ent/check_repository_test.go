@@ -5,6 +5,7 @@ import (
 	"github.com/gelleson/changescout/changescout/internal/domain"
 	"github.com/gelleson/changescout/changescout/internal/infrastructure/database"
 	"github.com/gelleson/changescout/changescout/internal/utils/testdb"
+	"github.com/gelleson/changescout/changescout/internal/utils/transform"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -80,6 +81,16 @@ func (s *CheckRepositoryTestSuite) TestCreateCheck() {
 			},
 			wantErr: false,
 		},
+		{
+			name: "create failed check without result",
+			check: domain.Check{
+				WebsiteID:    s.website.ID,
+				Status:       domain.CheckStatusProcessingFailed,
+				HasError:     true,
+				ErrorMessage: "no matches: h1",
+			},
+			wantErr: false,
+		},
 		{
 			name: "create check with non-existent website",
 			check: domain.Check{
@@ -102,6 +113,7 @@ func (s *CheckRepositoryTestSuite) TestCreateCheck() {
 			assert.NotEqual(s.T(), uuid.Nil, got.ID)
 			assert.Equal(s.T(), tt.check.WebsiteID, got.WebsiteID)
 			assert.Equal(s.T(), tt.check.Result, got.Result)
+			assert.Equal(s.T(), tt.check.Status, got.Status)
 			assert.NotZero(s.T(), got.CreatedAt)
 		})
 	}
@@ -342,3 +354,29 @@ func (s *CheckRepositoryTestSuite) TestGetLatestCheckByWebsite() {
 	assert.Equal(s.T(), latest.ID, got.ID)
 	assert.Equal(s.T(), latest.Result, got.Result)
 }
+
+func (s *CheckRepositoryTestSuite) TestGetSeries() {
+	checks := []domain.Check{
+		{WebsiteID: s.website.ID, Result: []byte("10"), Value: transform.ToPtr(10.0)},
+		{WebsiteID: s.website.ID, HasError: true, Status: domain.CheckStatusRequestFailed},
+		{WebsiteID: s.website.ID, Result: []byte("12.5"), Value: transform.ToPtr(12.5)},
+	}
+
+	for _, c := range checks {
+		_, err := s.checkRepo.CreateCheck(s.ctx, c)
+		assert.NoError(s.T(), err)
+		time.Sleep(time.Millisecond * 10) // Ensure different timestamps
+	}
+
+	got, err := s.checkRepo.GetSeries(s.ctx, database.CheckFilters{WebsiteID: &s.website.ID})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), got, 2)
+	assert.Equal(s.T(), 10.0, got[0].Value)
+	assert.Equal(s.T(), 12.5, got[1].Value)
+	assert.True(s.T(), got[0].CreatedAt.Before(got[1].CreatedAt))
+
+	future := time.Now().Add(time.Hour)
+	got, err = s.checkRepo.GetSeries(s.ctx, database.CheckFilters{WebsiteID: &s.website.ID, FromDate: &future})
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), got)
+}
@@ -27,12 +27,20 @@ type Check struct {
 	Result []byte `json:"result,omitempty"`
 	// HasError holds the value of the "has_error" field.
 	HasError bool `json:"has_error,omitempty"`
+	// Status holds the value of the "status" field.
+	Status string `json:"status,omitempty"`
 	// ErrorMessage holds the value of the "error_message" field.
 	ErrorMessage string `json:"error_message,omitempty"`
 	// HasDiff holds the value of the "has_diff" field.
 	HasDiff bool `json:"has_diff,omitempty"`
 	// DiffChange holds the value of the "diff_change" field.
 	DiffChange *diff.Result `json:"diff_change,omitempty"`
+	// Value holds the value of the "value" field.
+	Value *float64 `json:"value,omitempty"`
+	// ExitCode holds the value of the "exit_code" field.
+	ExitCode *int `json:"exit_code,omitempty"`
+	// Stderr holds the value of the "stderr" field.
+	Stderr string `json:"stderr,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -70,7 +78,11 @@ func (*Check) scanValues(columns []string) ([]any, error) {
 			values[i] = new([]byte)
 		case check.FieldHasError, check.FieldHasDiff:
 			values[i] = new(sql.NullBool)
-		case check.FieldErrorMessage:
+		case check.FieldValue:
+			values[i] = new(sql.NullFloat64)
+		case check.FieldExitCode:
+			values[i] = new(sql.NullInt64)
+		case check.FieldStatus, check.FieldErrorMessage, check.FieldStderr:
 			values[i] = new(sql.NullString)
 		case check.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
@@ -115,6 +127,12 @@ func (c *Check) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				c.HasError = value.Bool
 			}
+		case check.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				c.Status = value.String
+			}
 		case check.FieldErrorMessage:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field error_message", values[i])
@@ -135,6 +153,26 @@ func (c *Check) assignValues(columns []string, values []any) error {
 					return fmt.Errorf("unmarshal field diff_change: %w", err)
 				}
 			}
+		case check.FieldValue:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field value", values[i])
+			} else if value.Valid {
+				c.Value = new(float64)
+				*c.Value = value.Float64
+			}
+		case check.FieldExitCode:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field exit_code", values[i])
+			} else if value.Valid {
+				c.ExitCode = new(int)
+				*c.ExitCode = int(value.Int64)
+			}
+		case check.FieldStderr:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field stderr", values[i])
+			} else if value.Valid {
+				c.Stderr = value.String
+			}
 		case check.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -148,9 +186,9 @@ func (c *Check) assignValues(columns []string, values []any) error {
 	return nil
 }
 
-// Value returns the ent.Value that was dynamically selected and assigned to the Check.
+// GetValue returns the ent.Value that was dynamically selected and assigned to the Check.
 // This includes values selected through modifiers, order, etc.
-func (c *Check) Value(name string) (ent.Value, error) {
+func (c *Check) GetValue(name string) (ent.Value, error) {
 	return c.selectValues.Get(name)
 }
 
@@ -191,6 +229,9 @@ func (c *Check) String() string {
 	builder.WriteString("has_error=")
 	builder.WriteString(fmt.Sprintf("%v", c.HasError))
 	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(c.Status)
+	builder.WriteString(", ")
 	builder.WriteString("error_message=")
 	builder.WriteString(c.ErrorMessage)
 	builder.WriteString(", ")
@@ -200,6 +241,19 @@ func (c *Check) String() string {
 	builder.WriteString("diff_change=")
 	builder.WriteString(fmt.Sprintf("%v", c.DiffChange))
 	builder.WriteString(", ")
+	if v := c.Value; v != nil {
+		builder.WriteString("value=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := c.ExitCode; v != nil {
+		builder.WriteString("exit_code=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("stderr=")
+	builder.WriteString(c.Stderr)
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(c.CreatedAt.Format(time.ANSIC))
 	builder.WriteByte(')')
@@ -19,12 +19,20 @@ const (
 	FieldResult = "result"
 	// FieldHasError holds the string denoting the has_error field in the database.
 	FieldHasError = "has_error"
+	// FieldStatus holds the string denoting the status field in the database.
+	FieldStatus = "status"
 	// FieldErrorMessage holds the string denoting the error_message field in the database.
 	FieldErrorMessage = "error_message"
 	// FieldHasDiff holds the string denoting the has_diff field in the database.
 	FieldHasDiff = "has_diff"
 	// FieldDiffChange holds the string denoting the diff_change field in the database.
 	FieldDiffChange = "diff_change"
+	// FieldValue holds the string denoting the value field in the database.
+	FieldValue = "value"
+	// FieldExitCode holds the string denoting the exit_code field in the database.
+	FieldExitCode = "exit_code"
+	// FieldStderr holds the string denoting the stderr field in the database.
+	FieldStderr = "stderr"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// EdgeWebsite holds the string denoting the website edge name in mutations.
@@ -46,9 +54,13 @@ var Columns = []string{
 	FieldWebsiteID,
 	FieldResult,
 	FieldHasError,
+	FieldStatus,
 	FieldErrorMessage,
 	FieldHasDiff,
 	FieldDiffChange,
+	FieldValue,
+	FieldExitCode,
+	FieldStderr,
 	FieldCreatedAt,
 }
 
@@ -63,10 +75,10 @@ func ValidColumn(column string) bool {
 }
 
 var (
-	// ResultValidator is a validator for the "result" field. It is called by the builders before save.
-	ResultValidator func([]byte) error
 	// DefaultHasError holds the default value on creation for the "has_error" field.
 	DefaultHasError bool
+	// DefaultStatus holds the default value on creation for the "status" field.
+	DefaultStatus string
 	// DefaultHasDiff holds the default value on creation for the "has_diff" field.
 	DefaultHasDiff bool
 	// DefaultID holds the default value on creation for the "id" field.
@@ -91,6 +103,11 @@ func ByHasError(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldHasError, opts...).ToFunc()
 }
 
+// ByStatus orders the results by the status field.
+func ByStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStatus, opts...).ToFunc()
+}
+
 // ByErrorMessage orders the results by the error_message field.
 func ByErrorMessage(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldErrorMessage, opts...).ToFunc()
@@ -101,6 +118,21 @@ func ByHasDiff(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldHasDiff, opts...).ToFunc()
 }
 
+// ByValue orders the results by the value field.
+func ByValue(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldValue, opts...).ToFunc()
+}
+
+// ByExitCode orders the results by the exit_code field.
+func ByExitCode(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldExitCode, opts...).ToFunc()
+}
+
+// ByStderr orders the results by the stderr field.
+func ByStderr(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStderr, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
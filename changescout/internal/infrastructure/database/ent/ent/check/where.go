@@ -71,6 +71,11 @@ func HasError(v bool) predicate.Check {
 	return predicate.Check(sql.FieldEQ(FieldHasError, v))
 }
 
+// Status applies equality check predicate on the "status" field. It's identical to StatusEQ.
+func Status(v string) predicate.Check {
+	return predicate.Check(sql.FieldEQ(FieldStatus, v))
+}
+
 // ErrorMessage applies equality check predicate on the "error_message" field. It's identical to ErrorMessageEQ.
 func ErrorMessage(v string) predicate.Check {
 	return predicate.Check(sql.FieldEQ(FieldErrorMessage, v))
@@ -81,6 +86,21 @@ func HasDiff(v bool) predicate.Check {
 	return predicate.Check(sql.FieldEQ(FieldHasDiff, v))
 }
 
+// Value applies equality check predicate on the "value" field. It's identical to ValueEQ.
+func Value(v float64) predicate.Check {
+	return predicate.Check(sql.FieldEQ(FieldValue, v))
+}
+
+// ExitCode applies equality check predicate on the "exit_code" field. It's identical to ExitCodeEQ.
+func ExitCode(v int) predicate.Check {
+	return predicate.Check(sql.FieldEQ(FieldExitCode, v))
+}
+
+// Stderr applies equality check predicate on the "stderr" field. It's identical to StderrEQ.
+func Stderr(v string) predicate.Check {
+	return predicate.Check(sql.FieldEQ(FieldStderr, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.Check {
 	return predicate.Check(sql.FieldEQ(FieldCreatedAt, v))
@@ -156,6 +176,16 @@ func ResultLTE(v []byte) predicate.Check {
 	return predicate.Check(sql.FieldLTE(FieldResult, v))
 }
 
+// ResultIsNil applies the IsNil predicate on the "result" field.
+func ResultIsNil() predicate.Check {
+	return predicate.Check(sql.FieldIsNull(FieldResult))
+}
+
+// ResultNotNil applies the NotNil predicate on the "result" field.
+func ResultNotNil() predicate.Check {
+	return predicate.Check(sql.FieldNotNull(FieldResult))
+}
+
 // HasErrorEQ applies the EQ predicate on the "has_error" field.
 func HasErrorEQ(v bool) predicate.Check {
 	return predicate.Check(sql.FieldEQ(FieldHasError, v))
@@ -166,6 +196,71 @@ func HasErrorNEQ(v bool) predicate.Check {
 	return predicate.Check(sql.FieldNEQ(FieldHasError, v))
 }
 
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v string) predicate.Check {
+	return predicate.Check(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v string) predicate.Check {
+	return predicate.Check(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...string) predicate.Check {
+	return predicate.Check(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...string) predicate.Check {
+	return predicate.Check(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// StatusGT applies the GT predicate on the "status" field.
+func StatusGT(v string) predicate.Check {
+	return predicate.Check(sql.FieldGT(FieldStatus, v))
+}
+
+// StatusGTE applies the GTE predicate on the "status" field.
+func StatusGTE(v string) predicate.Check {
+	return predicate.Check(sql.FieldGTE(FieldStatus, v))
+}
+
+// StatusLT applies the LT predicate on the "status" field.
+func StatusLT(v string) predicate.Check {
+	return predicate.Check(sql.FieldLT(FieldStatus, v))
+}
+
+// StatusLTE applies the LTE predicate on the "status" field.
+func StatusLTE(v string) predicate.Check {
+	return predicate.Check(sql.FieldLTE(FieldStatus, v))
+}
+
+// StatusContains applies the Contains predicate on the "status" field.
+func StatusContains(v string) predicate.Check {
+	return predicate.Check(sql.FieldContains(FieldStatus, v))
+}
+
+// StatusHasPrefix applies the HasPrefix predicate on the "status" field.
+func StatusHasPrefix(v string) predicate.Check {
+	return predicate.Check(sql.FieldHasPrefix(FieldStatus, v))
+}
+
+// StatusHasSuffix applies the HasSuffix predicate on the "status" field.
+func StatusHasSuffix(v string) predicate.Check {
+	return predicate.Check(sql.FieldHasSuffix(FieldStatus, v))
+}
+
+// StatusEqualFold applies the EqualFold predicate on the "status" field.
+func StatusEqualFold(v string) predicate.Check {
+	return predicate.Check(sql.FieldEqualFold(FieldStatus, v))
+}
+
+// StatusContainsFold applies the ContainsFold predicate on the "status" field.
+func StatusContainsFold(v string) predicate.Check {
+	return predicate.Check(sql.FieldContainsFold(FieldStatus, v))
+}
+
 // ErrorMessageEQ applies the EQ predicate on the "error_message" field.
 func ErrorMessageEQ(v string) predicate.Check {
 	return predicate.Check(sql.FieldEQ(FieldErrorMessage, v))
@@ -261,6 +356,181 @@ func DiffChangeNotNil() predicate.Check {
 	return predicate.Check(sql.FieldNotNull(FieldDiffChange))
 }
 
+// ValueEQ applies the EQ predicate on the "value" field.
+func ValueEQ(v float64) predicate.Check {
+	return predicate.Check(sql.FieldEQ(FieldValue, v))
+}
+
+// ValueNEQ applies the NEQ predicate on the "value" field.
+func ValueNEQ(v float64) predicate.Check {
+	return predicate.Check(sql.FieldNEQ(FieldValue, v))
+}
+
+// ValueIn applies the In predicate on the "value" field.
+func ValueIn(vs ...float64) predicate.Check {
+	return predicate.Check(sql.FieldIn(FieldValue, vs...))
+}
+
+// ValueNotIn applies the NotIn predicate on the "value" field.
+func ValueNotIn(vs ...float64) predicate.Check {
+	return predicate.Check(sql.FieldNotIn(FieldValue, vs...))
+}
+
+// ValueGT applies the GT predicate on the "value" field.
+func ValueGT(v float64) predicate.Check {
+	return predicate.Check(sql.FieldGT(FieldValue, v))
+}
+
+// ValueGTE applies the GTE predicate on the "value" field.
+func ValueGTE(v float64) predicate.Check {
+	return predicate.Check(sql.FieldGTE(FieldValue, v))
+}
+
+// ValueLT applies the LT predicate on the "value" field.
+func ValueLT(v float64) predicate.Check {
+	return predicate.Check(sql.FieldLT(FieldValue, v))
+}
+
+// ValueLTE applies the LTE predicate on the "value" field.
+func ValueLTE(v float64) predicate.Check {
+	return predicate.Check(sql.FieldLTE(FieldValue, v))
+}
+
+// ValueIsNil applies the IsNil predicate on the "value" field.
+func ValueIsNil() predicate.Check {
+	return predicate.Check(sql.FieldIsNull(FieldValue))
+}
+
+// ValueNotNil applies the NotNil predicate on the "value" field.
+func ValueNotNil() predicate.Check {
+	return predicate.Check(sql.FieldNotNull(FieldValue))
+}
+
+// ExitCodeEQ applies the EQ predicate on the "exit_code" field.
+func ExitCodeEQ(v int) predicate.Check {
+	return predicate.Check(sql.FieldEQ(FieldExitCode, v))
+}
+
+// ExitCodeNEQ applies the NEQ predicate on the "exit_code" field.
+func ExitCodeNEQ(v int) predicate.Check {
+	return predicate.Check(sql.FieldNEQ(FieldExitCode, v))
+}
+
+// ExitCodeIn applies the In predicate on the "exit_code" field.
+func ExitCodeIn(vs ...int) predicate.Check {
+	return predicate.Check(sql.FieldIn(FieldExitCode, vs...))
+}
+
+// ExitCodeNotIn applies the NotIn predicate on the "exit_code" field.
+func ExitCodeNotIn(vs ...int) predicate.Check {
+	return predicate.Check(sql.FieldNotIn(FieldExitCode, vs...))
+}
+
+// ExitCodeGT applies the GT predicate on the "exit_code" field.
+func ExitCodeGT(v int) predicate.Check {
+	return predicate.Check(sql.FieldGT(FieldExitCode, v))
+}
+
+// ExitCodeGTE applies the GTE predicate on the "exit_code" field.
+func ExitCodeGTE(v int) predicate.Check {
+	return predicate.Check(sql.FieldGTE(FieldExitCode, v))
+}
+
+// ExitCodeLT applies the LT predicate on the "exit_code" field.
+func ExitCodeLT(v int) predicate.Check {
+	return predicate.Check(sql.FieldLT(FieldExitCode, v))
+}
+
+// ExitCodeLTE applies the LTE predicate on the "exit_code" field.
+func ExitCodeLTE(v int) predicate.Check {
+	return predicate.Check(sql.FieldLTE(FieldExitCode, v))
+}
+
+// ExitCodeIsNil applies the IsNil predicate on the "exit_code" field.
+func ExitCodeIsNil() predicate.Check {
+	return predicate.Check(sql.FieldIsNull(FieldExitCode))
+}
+
+// ExitCodeNotNil applies the NotNil predicate on the "exit_code" field.
+func ExitCodeNotNil() predicate.Check {
+	return predicate.Check(sql.FieldNotNull(FieldExitCode))
+}
+
+// StderrEQ applies the EQ predicate on the "stderr" field.
+func StderrEQ(v string) predicate.Check {
+	return predicate.Check(sql.FieldEQ(FieldStderr, v))
+}
+
+// StderrNEQ applies the NEQ predicate on the "stderr" field.
+func StderrNEQ(v string) predicate.Check {
+	return predicate.Check(sql.FieldNEQ(FieldStderr, v))
+}
+
+// StderrIn applies the In predicate on the "stderr" field.
+func StderrIn(vs ...string) predicate.Check {
+	return predicate.Check(sql.FieldIn(FieldStderr, vs...))
+}
+
+// StderrNotIn applies the NotIn predicate on the "stderr" field.
+func StderrNotIn(vs ...string) predicate.Check {
+	return predicate.Check(sql.FieldNotIn(FieldStderr, vs...))
+}
+
+// StderrGT applies the GT predicate on the "stderr" field.
+func StderrGT(v string) predicate.Check {
+	return predicate.Check(sql.FieldGT(FieldStderr, v))
+}
+
+// StderrGTE applies the GTE predicate on the "stderr" field.
+func StderrGTE(v string) predicate.Check {
+	return predicate.Check(sql.FieldGTE(FieldStderr, v))
+}
+
+// StderrLT applies the LT predicate on the "stderr" field.
+func StderrLT(v string) predicate.Check {
+	return predicate.Check(sql.FieldLT(FieldStderr, v))
+}
+
+// StderrLTE applies the LTE predicate on the "stderr" field.
+func StderrLTE(v string) predicate.Check {
+	return predicate.Check(sql.FieldLTE(FieldStderr, v))
+}
+
+// StderrContains applies the Contains predicate on the "stderr" field.
+func StderrContains(v string) predicate.Check {
+	return predicate.Check(sql.FieldContains(FieldStderr, v))
+}
+
+// StderrHasPrefix applies the HasPrefix predicate on the "stderr" field.
+func StderrHasPrefix(v string) predicate.Check {
+	return predicate.Check(sql.FieldHasPrefix(FieldStderr, v))
+}
+
+// StderrHasSuffix applies the HasSuffix predicate on the "stderr" field.
+func StderrHasSuffix(v string) predicate.Check {
+	return predicate.Check(sql.FieldHasSuffix(FieldStderr, v))
+}
+
+// StderrIsNil applies the IsNil predicate on the "stderr" field.
+func StderrIsNil() predicate.Check {
+	return predicate.Check(sql.FieldIsNull(FieldStderr))
+}
+
+// StderrNotNil applies the NotNil predicate on the "stderr" field.
+func StderrNotNil() predicate.Check {
+	return predicate.Check(sql.FieldNotNull(FieldStderr))
+}
+
+// StderrEqualFold applies the EqualFold predicate on the "stderr" field.
+func StderrEqualFold(v string) predicate.Check {
+	return predicate.Check(sql.FieldEqualFold(FieldStderr, v))
+}
+
+// StderrContainsFold applies the ContainsFold predicate on the "stderr" field.
+func StderrContainsFold(v string) predicate.Check {
+	return predicate.Check(sql.FieldContainsFold(FieldStderr, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Check {
 	return predicate.Check(sql.FieldEQ(FieldCreatedAt, v))
@@ -57,6 +57,20 @@ func (cc *CheckCreate) SetNillableHasError(b *bool) *CheckCreate {
 	return cc
 }
 
+// SetStatus sets the "status" field.
+func (cc *CheckCreate) SetStatus(s string) *CheckCreate {
+	cc.mutation.SetStatus(s)
+	return cc
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (cc *CheckCreate) SetNillableStatus(s *string) *CheckCreate {
+	if s != nil {
+		cc.SetStatus(*s)
+	}
+	return cc
+}
+
 // SetErrorMessage sets the "error_message" field.
 func (cc *CheckCreate) SetErrorMessage(s string) *CheckCreate {
 	cc.mutation.SetErrorMessage(s)
@@ -91,6 +105,48 @@ func (cc *CheckCreate) SetDiffChange(d *diff.Result) *CheckCreate {
 	return cc
 }
 
+// SetValue sets the "value" field.
+func (cc *CheckCreate) SetValue(f float64) *CheckCreate {
+	cc.mutation.SetValue(f)
+	return cc
+}
+
+// SetNillableValue sets the "value" field if the given value is not nil.
+func (cc *CheckCreate) SetNillableValue(f *float64) *CheckCreate {
+	if f != nil {
+		cc.SetValue(*f)
+	}
+	return cc
+}
+
+// SetExitCode sets the "exit_code" field.
+func (cc *CheckCreate) SetExitCode(i int) *CheckCreate {
+	cc.mutation.SetExitCode(i)
+	return cc
+}
+
+// SetNillableExitCode sets the "exit_code" field if the given value is not nil.
+func (cc *CheckCreate) SetNillableExitCode(i *int) *CheckCreate {
+	if i != nil {
+		cc.SetExitCode(*i)
+	}
+	return cc
+}
+
+// SetStderr sets the "stderr" field.
+func (cc *CheckCreate) SetStderr(s string) *CheckCreate {
+	cc.mutation.SetStderr(s)
+	return cc
+}
+
+// SetNillableStderr sets the "stderr" field if the given value is not nil.
+func (cc *CheckCreate) SetNillableStderr(s *string) *CheckCreate {
+	if s != nil {
+		cc.SetStderr(*s)
+	}
+	return cc
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (cc *CheckCreate) SetCreatedAt(t time.Time) *CheckCreate {
 	cc.mutation.SetCreatedAt(t)
@@ -155,6 +211,10 @@ func (cc *CheckCreate) defaults() {
 		v := check.DefaultHasError
 		cc.mutation.SetHasError(v)
 	}
+	if _, ok := cc.mutation.Status(); !ok {
+		v := check.DefaultStatus
+		cc.mutation.SetStatus(v)
+	}
 	if _, ok := cc.mutation.HasDiff(); !ok {
 		v := check.DefaultHasDiff
 		cc.mutation.SetHasDiff(v)
@@ -167,17 +227,12 @@ func (cc *CheckCreate) defaults() {
 
 // check runs all checks and user-defined validators on the builder.
 func (cc *CheckCreate) check() error {
-	if _, ok := cc.mutation.Result(); !ok {
-		return &ValidationError{Name: "result", err: errors.New(`ent: missing required field "Check.result"`)}
-	}
-	if v, ok := cc.mutation.Result(); ok {
-		if err := check.ResultValidator(v); err != nil {
-			return &ValidationError{Name: "result", err: fmt.Errorf(`ent: validator failed for field "Check.result": %w`, err)}
-		}
-	}
 	if _, ok := cc.mutation.HasError(); !ok {
 		return &ValidationError{Name: "has_error", err: errors.New(`ent: missing required field "Check.has_error"`)}
 	}
+	if _, ok := cc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Check.status"`)}
+	}
 	if _, ok := cc.mutation.HasDiff(); !ok {
 		return &ValidationError{Name: "has_diff", err: errors.New(`ent: missing required field "Check.has_diff"`)}
 	}
@@ -227,6 +282,10 @@ func (cc *CheckCreate) createSpec() (*Check, *sqlgraph.CreateSpec) {
 		_spec.SetField(check.FieldHasError, field.TypeBool, value)
 		_node.HasError = value
 	}
+	if value, ok := cc.mutation.Status(); ok {
+		_spec.SetField(check.FieldStatus, field.TypeString, value)
+		_node.Status = value
+	}
 	if value, ok := cc.mutation.ErrorMessage(); ok {
 		_spec.SetField(check.FieldErrorMessage, field.TypeString, value)
 		_node.ErrorMessage = value
@@ -239,6 +298,18 @@ func (cc *CheckCreate) createSpec() (*Check, *sqlgraph.CreateSpec) {
 		_spec.SetField(check.FieldDiffChange, field.TypeJSON, value)
 		_node.DiffChange = value
 	}
+	if value, ok := cc.mutation.Value(); ok {
+		_spec.SetField(check.FieldValue, field.TypeFloat64, value)
+		_node.Value = &value
+	}
+	if value, ok := cc.mutation.ExitCode(); ok {
+		_spec.SetField(check.FieldExitCode, field.TypeInt, value)
+		_node.ExitCode = &value
+	}
+	if value, ok := cc.mutation.Stderr(); ok {
+		_spec.SetField(check.FieldStderr, field.TypeString, value)
+		_node.Stderr = value
+	}
 	if value, ok := cc.mutation.CreatedAt(); ok {
 		_spec.SetField(check.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -57,6 +57,12 @@ func (cu *CheckUpdate) SetResult(b []byte) *CheckUpdate {
 	return cu
 }
 
+// ClearResult clears the value of the "result" field.
+func (cu *CheckUpdate) ClearResult() *CheckUpdate {
+	cu.mutation.ClearResult()
+	return cu
+}
+
 // SetHasError sets the "has_error" field.
 func (cu *CheckUpdate) SetHasError(b bool) *CheckUpdate {
 	cu.mutation.SetHasError(b)
@@ -71,6 +77,20 @@ func (cu *CheckUpdate) SetNillableHasError(b *bool) *CheckUpdate {
 	return cu
 }
 
+// SetStatus sets the "status" field.
+func (cu *CheckUpdate) SetStatus(s string) *CheckUpdate {
+	cu.mutation.SetStatus(s)
+	return cu
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (cu *CheckUpdate) SetNillableStatus(s *string) *CheckUpdate {
+	if s != nil {
+		cu.SetStatus(*s)
+	}
+	return cu
+}
+
 // SetErrorMessage sets the "error_message" field.
 func (cu *CheckUpdate) SetErrorMessage(s string) *CheckUpdate {
 	cu.mutation.SetErrorMessage(s)
@@ -117,6 +137,80 @@ func (cu *CheckUpdate) ClearDiffChange() *CheckUpdate {
 	return cu
 }
 
+// SetValue sets the "value" field.
+func (cu *CheckUpdate) SetValue(f float64) *CheckUpdate {
+	cu.mutation.ResetValue()
+	cu.mutation.SetValue(f)
+	return cu
+}
+
+// SetNillableValue sets the "value" field if the given value is not nil.
+func (cu *CheckUpdate) SetNillableValue(f *float64) *CheckUpdate {
+	if f != nil {
+		cu.SetValue(*f)
+	}
+	return cu
+}
+
+// AddValue adds f to the "value" field.
+func (cu *CheckUpdate) AddValue(f float64) *CheckUpdate {
+	cu.mutation.AddValue(f)
+	return cu
+}
+
+// ClearValue clears the value of the "value" field.
+func (cu *CheckUpdate) ClearValue() *CheckUpdate {
+	cu.mutation.ClearValue()
+	return cu
+}
+
+// SetExitCode sets the "exit_code" field.
+func (cu *CheckUpdate) SetExitCode(i int) *CheckUpdate {
+	cu.mutation.ResetExitCode()
+	cu.mutation.SetExitCode(i)
+	return cu
+}
+
+// SetNillableExitCode sets the "exit_code" field if the given value is not nil.
+func (cu *CheckUpdate) SetNillableExitCode(i *int) *CheckUpdate {
+	if i != nil {
+		cu.SetExitCode(*i)
+	}
+	return cu
+}
+
+// AddExitCode adds i to the "exit_code" field.
+func (cu *CheckUpdate) AddExitCode(i int) *CheckUpdate {
+	cu.mutation.AddExitCode(i)
+	return cu
+}
+
+// ClearExitCode clears the value of the "exit_code" field.
+func (cu *CheckUpdate) ClearExitCode() *CheckUpdate {
+	cu.mutation.ClearExitCode()
+	return cu
+}
+
+// SetStderr sets the "stderr" field.
+func (cu *CheckUpdate) SetStderr(s string) *CheckUpdate {
+	cu.mutation.SetStderr(s)
+	return cu
+}
+
+// SetNillableStderr sets the "stderr" field if the given value is not nil.
+func (cu *CheckUpdate) SetNillableStderr(s *string) *CheckUpdate {
+	if s != nil {
+		cu.SetStderr(*s)
+	}
+	return cu
+}
+
+// ClearStderr clears the value of the "stderr" field.
+func (cu *CheckUpdate) ClearStderr() *CheckUpdate {
+	cu.mutation.ClearStderr()
+	return cu
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (cu *CheckUpdate) SetCreatedAt(t time.Time) *CheckUpdate {
 	cu.mutation.SetCreatedAt(t)
@@ -174,20 +268,7 @@ func (cu *CheckUpdate) ExecX(ctx context.Context) {
 	}
 }
 
-// check runs all checks and user-defined validators on the builder.
-func (cu *CheckUpdate) check() error {
-	if v, ok := cu.mutation.Result(); ok {
-		if err := check.ResultValidator(v); err != nil {
-			return &ValidationError{Name: "result", err: fmt.Errorf(`ent: validator failed for field "Check.result": %w`, err)}
-		}
-	}
-	return nil
-}
-
 func (cu *CheckUpdate) sqlSave(ctx context.Context) (n int, err error) {
-	if err := cu.check(); err != nil {
-		return n, err
-	}
 	_spec := sqlgraph.NewUpdateSpec(check.Table, check.Columns, sqlgraph.NewFieldSpec(check.FieldID, field.TypeUUID))
 	if ps := cu.mutation.predicates; len(ps) > 0 {
 		_spec.Predicate = func(selector *sql.Selector) {
@@ -199,9 +280,15 @@ func (cu *CheckUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := cu.mutation.Result(); ok {
 		_spec.SetField(check.FieldResult, field.TypeBytes, value)
 	}
+	if cu.mutation.ResultCleared() {
+		_spec.ClearField(check.FieldResult, field.TypeBytes)
+	}
 	if value, ok := cu.mutation.HasError(); ok {
 		_spec.SetField(check.FieldHasError, field.TypeBool, value)
 	}
+	if value, ok := cu.mutation.Status(); ok {
+		_spec.SetField(check.FieldStatus, field.TypeString, value)
+	}
 	if value, ok := cu.mutation.ErrorMessage(); ok {
 		_spec.SetField(check.FieldErrorMessage, field.TypeString, value)
 	}
@@ -217,6 +304,30 @@ func (cu *CheckUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if cu.mutation.DiffChangeCleared() {
 		_spec.ClearField(check.FieldDiffChange, field.TypeJSON)
 	}
+	if value, ok := cu.mutation.Value(); ok {
+		_spec.SetField(check.FieldValue, field.TypeFloat64, value)
+	}
+	if value, ok := cu.mutation.AddedValue(); ok {
+		_spec.AddField(check.FieldValue, field.TypeFloat64, value)
+	}
+	if cu.mutation.ValueCleared() {
+		_spec.ClearField(check.FieldValue, field.TypeFloat64)
+	}
+	if value, ok := cu.mutation.ExitCode(); ok {
+		_spec.SetField(check.FieldExitCode, field.TypeInt, value)
+	}
+	if value, ok := cu.mutation.AddedExitCode(); ok {
+		_spec.AddField(check.FieldExitCode, field.TypeInt, value)
+	}
+	if cu.mutation.ExitCodeCleared() {
+		_spec.ClearField(check.FieldExitCode, field.TypeInt)
+	}
+	if value, ok := cu.mutation.Stderr(); ok {
+		_spec.SetField(check.FieldStderr, field.TypeString, value)
+	}
+	if cu.mutation.StderrCleared() {
+		_spec.ClearField(check.FieldStderr, field.TypeString)
+	}
 	if value, ok := cu.mutation.CreatedAt(); ok {
 		_spec.SetField(check.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -295,6 +406,12 @@ func (cuo *CheckUpdateOne) SetResult(b []byte) *CheckUpdateOne {
 	return cuo
 }
 
+// ClearResult clears the value of the "result" field.
+func (cuo *CheckUpdateOne) ClearResult() *CheckUpdateOne {
+	cuo.mutation.ClearResult()
+	return cuo
+}
+
 // SetHasError sets the "has_error" field.
 func (cuo *CheckUpdateOne) SetHasError(b bool) *CheckUpdateOne {
 	cuo.mutation.SetHasError(b)
@@ -309,6 +426,20 @@ func (cuo *CheckUpdateOne) SetNillableHasError(b *bool) *CheckUpdateOne {
 	return cuo
 }
 
+// SetStatus sets the "status" field.
+func (cuo *CheckUpdateOne) SetStatus(s string) *CheckUpdateOne {
+	cuo.mutation.SetStatus(s)
+	return cuo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (cuo *CheckUpdateOne) SetNillableStatus(s *string) *CheckUpdateOne {
+	if s != nil {
+		cuo.SetStatus(*s)
+	}
+	return cuo
+}
+
 // SetErrorMessage sets the "error_message" field.
 func (cuo *CheckUpdateOne) SetErrorMessage(s string) *CheckUpdateOne {
 	cuo.mutation.SetErrorMessage(s)
@@ -355,6 +486,80 @@ func (cuo *CheckUpdateOne) ClearDiffChange() *CheckUpdateOne {
 	return cuo
 }
 
+// SetValue sets the "value" field.
+func (cuo *CheckUpdateOne) SetValue(f float64) *CheckUpdateOne {
+	cuo.mutation.ResetValue()
+	cuo.mutation.SetValue(f)
+	return cuo
+}
+
+// SetNillableValue sets the "value" field if the given value is not nil.
+func (cuo *CheckUpdateOne) SetNillableValue(f *float64) *CheckUpdateOne {
+	if f != nil {
+		cuo.SetValue(*f)
+	}
+	return cuo
+}
+
+// AddValue adds f to the "value" field.
+func (cuo *CheckUpdateOne) AddValue(f float64) *CheckUpdateOne {
+	cuo.mutation.AddValue(f)
+	return cuo
+}
+
+// ClearValue clears the value of the "value" field.
+func (cuo *CheckUpdateOne) ClearValue() *CheckUpdateOne {
+	cuo.mutation.ClearValue()
+	return cuo
+}
+
+// SetExitCode sets the "exit_code" field.
+func (cuo *CheckUpdateOne) SetExitCode(i int) *CheckUpdateOne {
+	cuo.mutation.ResetExitCode()
+	cuo.mutation.SetExitCode(i)
+	return cuo
+}
+
+// SetNillableExitCode sets the "exit_code" field if the given value is not nil.
+func (cuo *CheckUpdateOne) SetNillableExitCode(i *int) *CheckUpdateOne {
+	if i != nil {
+		cuo.SetExitCode(*i)
+	}
+	return cuo
+}
+
+// AddExitCode adds i to the "exit_code" field.
+func (cuo *CheckUpdateOne) AddExitCode(i int) *CheckUpdateOne {
+	cuo.mutation.AddExitCode(i)
+	return cuo
+}
+
+// ClearExitCode clears the value of the "exit_code" field.
+func (cuo *CheckUpdateOne) ClearExitCode() *CheckUpdateOne {
+	cuo.mutation.ClearExitCode()
+	return cuo
+}
+
+// SetStderr sets the "stderr" field.
+func (cuo *CheckUpdateOne) SetStderr(s string) *CheckUpdateOne {
+	cuo.mutation.SetStderr(s)
+	return cuo
+}
+
+// SetNillableStderr sets the "stderr" field if the given value is not nil.
+func (cuo *CheckUpdateOne) SetNillableStderr(s *string) *CheckUpdateOne {
+	if s != nil {
+		cuo.SetStderr(*s)
+	}
+	return cuo
+}
+
+// ClearStderr clears the value of the "stderr" field.
+func (cuo *CheckUpdateOne) ClearStderr() *CheckUpdateOne {
+	cuo.mutation.ClearStderr()
+	return cuo
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (cuo *CheckUpdateOne) SetCreatedAt(t time.Time) *CheckUpdateOne {
 	cuo.mutation.SetCreatedAt(t)
@@ -425,20 +630,7 @@ func (cuo *CheckUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
-// check runs all checks and user-defined validators on the builder.
-func (cuo *CheckUpdateOne) check() error {
-	if v, ok := cuo.mutation.Result(); ok {
-		if err := check.ResultValidator(v); err != nil {
-			return &ValidationError{Name: "result", err: fmt.Errorf(`ent: validator failed for field "Check.result": %w`, err)}
-		}
-	}
-	return nil
-}
-
 func (cuo *CheckUpdateOne) sqlSave(ctx context.Context) (_node *Check, err error) {
-	if err := cuo.check(); err != nil {
-		return _node, err
-	}
 	_spec := sqlgraph.NewUpdateSpec(check.Table, check.Columns, sqlgraph.NewFieldSpec(check.FieldID, field.TypeUUID))
 	id, ok := cuo.mutation.ID()
 	if !ok {
@@ -467,9 +659,15 @@ func (cuo *CheckUpdateOne) sqlSave(ctx context.Context) (_node *Check, err error
 	if value, ok := cuo.mutation.Result(); ok {
 		_spec.SetField(check.FieldResult, field.TypeBytes, value)
 	}
+	if cuo.mutation.ResultCleared() {
+		_spec.ClearField(check.FieldResult, field.TypeBytes)
+	}
 	if value, ok := cuo.mutation.HasError(); ok {
 		_spec.SetField(check.FieldHasError, field.TypeBool, value)
 	}
+	if value, ok := cuo.mutation.Status(); ok {
+		_spec.SetField(check.FieldStatus, field.TypeString, value)
+	}
 	if value, ok := cuo.mutation.ErrorMessage(); ok {
 		_spec.SetField(check.FieldErrorMessage, field.TypeString, value)
 	}
@@ -485,6 +683,30 @@ func (cuo *CheckUpdateOne) sqlSave(ctx context.Context) (_node *Check, err error
 	if cuo.mutation.DiffChangeCleared() {
 		_spec.ClearField(check.FieldDiffChange, field.TypeJSON)
 	}
+	if value, ok := cuo.mutation.Value(); ok {
+		_spec.SetField(check.FieldValue, field.TypeFloat64, value)
+	}
+	if value, ok := cuo.mutation.AddedValue(); ok {
+		_spec.AddField(check.FieldValue, field.TypeFloat64, value)
+	}
+	if cuo.mutation.ValueCleared() {
+		_spec.ClearField(check.FieldValue, field.TypeFloat64)
+	}
+	if value, ok := cuo.mutation.ExitCode(); ok {
+		_spec.SetField(check.FieldExitCode, field.TypeInt, value)
+	}
+	if value, ok := cuo.mutation.AddedExitCode(); ok {
+		_spec.AddField(check.FieldExitCode, field.TypeInt, value)
+	}
+	if cuo.mutation.ExitCodeCleared() {
+		_spec.ClearField(check.FieldExitCode, field.TypeInt)
+	}
+	if value, ok := cuo.mutation.Stderr(); ok {
+		_spec.SetField(check.FieldStderr, field.TypeString, value)
+	}
+	if cuo.mutation.StderrCleared() {
+		_spec.ClearField(check.FieldStderr, field.TypeString)
+	}
 	if value, ok := cuo.mutation.CreatedAt(); ok {
 		_spec.SetField(check.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -12,11 +12,15 @@ var (
 	// ChecksColumns holds the columns for the "checks" table.
 	ChecksColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
-		{Name: "result", Type: field.TypeBytes},
+		{Name: "result", Type: field.TypeBytes, Nullable: true},
 		{Name: "has_error", Type: field.TypeBool, Default: false},
+		{Name: "status", Type: field.TypeString, Default: "ok"},
 		{Name: "error_message", Type: field.TypeString, Nullable: true},
 		{Name: "has_diff", Type: field.TypeBool, Default: false},
 		{Name: "diff_change", Type: field.TypeJSON, Nullable: true},
+		{Name: "value", Type: field.TypeFloat64, Nullable: true},
+		{Name: "exit_code", Type: field.TypeInt, Nullable: true},
+		{Name: "stderr", Type: field.TypeString, Nullable: true, Size: 2147483647},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "website_id", Type: field.TypeUUID, Nullable: true},
 	}
@@ -28,7 +32,7 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "checks_websites_website",
-				Columns:    []*schema.Column{ChecksColumns[7]},
+				Columns:    []*schema.Column{ChecksColumns[11]},
 				RefColumns: []*schema.Column{WebsitesColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
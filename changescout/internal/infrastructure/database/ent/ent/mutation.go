@@ -45,9 +45,15 @@ type CheckMutation struct {
 	id             *uuid.UUID
 	result         *[]byte
 	has_error      *bool
+	status         *string
 	error_message  *string
 	has_diff       *bool
 	diff_change    **diff.Result
+	value          *float64
+	addvalue       *float64
+	exit_code      *int
+	addexit_code   *int
+	stderr         *string
 	created_at     *time.Time
 	clearedFields  map[string]struct{}
 	website        *uuid.UUID
@@ -241,9 +247,22 @@ func (m *CheckMutation) OldResult(ctx context.Context) (v []byte, err error) {
 	return oldValue.Result, nil
 }
 
+// ClearResult clears the value of the "result" field.
+func (m *CheckMutation) ClearResult() {
+	m.result = nil
+	m.clearedFields[check.FieldResult] = struct{}{}
+}
+
+// ResultCleared returns if the "result" field was cleared in this mutation.
+func (m *CheckMutation) ResultCleared() bool {
+	_, ok := m.clearedFields[check.FieldResult]
+	return ok
+}
+
 // ResetResult resets all changes to the "result" field.
 func (m *CheckMutation) ResetResult() {
 	m.result = nil
+	delete(m.clearedFields, check.FieldResult)
 }
 
 // SetHasError sets the "has_error" field.
@@ -282,6 +301,42 @@ func (m *CheckMutation) ResetHasError() {
 	m.has_error = nil
 }
 
+// SetStatus sets the "status" field.
+func (m *CheckMutation) SetStatus(s string) {
+	m.status = &s
+}
+
+// Status returns the value of the "status" field in the mutation.
+func (m *CheckMutation) Status() (r string, exists bool) {
+	v := m.status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStatus returns the old "status" field's value of the Check entity.
+// If the Check object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CheckMutation) OldStatus(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+	}
+	return oldValue.Status, nil
+}
+
+// ResetStatus resets all changes to the "status" field.
+func (m *CheckMutation) ResetStatus() {
+	m.status = nil
+}
+
 // SetErrorMessage sets the "error_message" field.
 func (m *CheckMutation) SetErrorMessage(s string) {
 	m.error_message = &s
@@ -416,6 +471,195 @@ func (m *CheckMutation) ResetDiffChange() {
 	delete(m.clearedFields, check.FieldDiffChange)
 }
 
+// SetValue sets the "value" field.
+func (m *CheckMutation) SetValue(f float64) {
+	m.value = &f
+	m.addvalue = nil
+}
+
+// Value returns the value of the "value" field in the mutation.
+func (m *CheckMutation) Value() (r float64, exists bool) {
+	v := m.value
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldValue returns the old "value" field's value of the Check entity.
+// If the Check object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CheckMutation) OldValue(ctx context.Context) (v *float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldValue is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldValue requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldValue: %w", err)
+	}
+	return oldValue.Value, nil
+}
+
+// AddValue adds f to the "value" field.
+func (m *CheckMutation) AddValue(f float64) {
+	if m.addvalue != nil {
+		*m.addvalue += f
+	} else {
+		m.addvalue = &f
+	}
+}
+
+// AddedValue returns the value that was added to the "value" field in this mutation.
+func (m *CheckMutation) AddedValue() (r float64, exists bool) {
+	v := m.addvalue
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearValue clears the value of the "value" field.
+func (m *CheckMutation) ClearValue() {
+	m.value = nil
+	m.addvalue = nil
+	m.clearedFields[check.FieldValue] = struct{}{}
+}
+
+// ValueCleared returns if the "value" field was cleared in this mutation.
+func (m *CheckMutation) ValueCleared() bool {
+	_, ok := m.clearedFields[check.FieldValue]
+	return ok
+}
+
+// ResetValue resets all changes to the "value" field.
+func (m *CheckMutation) ResetValue() {
+	m.value = nil
+	m.addvalue = nil
+	delete(m.clearedFields, check.FieldValue)
+}
+
+// SetExitCode sets the "exit_code" field.
+func (m *CheckMutation) SetExitCode(i int) {
+	m.exit_code = &i
+	m.addexit_code = nil
+}
+
+// ExitCode returns the value of the "exit_code" field in the mutation.
+func (m *CheckMutation) ExitCode() (r int, exists bool) {
+	v := m.exit_code
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExitCode returns the old "exit_code" field's value of the Check entity.
+// If the Check object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CheckMutation) OldExitCode(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExitCode is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExitCode requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExitCode: %w", err)
+	}
+	return oldValue.ExitCode, nil
+}
+
+// AddExitCode adds i to the "exit_code" field.
+func (m *CheckMutation) AddExitCode(i int) {
+	if m.addexit_code != nil {
+		*m.addexit_code += i
+	} else {
+		m.addexit_code = &i
+	}
+}
+
+// AddedExitCode returns the value that was added to the "exit_code" field in this mutation.
+func (m *CheckMutation) AddedExitCode() (r int, exists bool) {
+	v := m.addexit_code
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearExitCode clears the value of the "exit_code" field.
+func (m *CheckMutation) ClearExitCode() {
+	m.exit_code = nil
+	m.addexit_code = nil
+	m.clearedFields[check.FieldExitCode] = struct{}{}
+}
+
+// ExitCodeCleared returns if the "exit_code" field was cleared in this mutation.
+func (m *CheckMutation) ExitCodeCleared() bool {
+	_, ok := m.clearedFields[check.FieldExitCode]
+	return ok
+}
+
+// ResetExitCode resets all changes to the "exit_code" field.
+func (m *CheckMutation) ResetExitCode() {
+	m.exit_code = nil
+	m.addexit_code = nil
+	delete(m.clearedFields, check.FieldExitCode)
+}
+
+// SetStderr sets the "stderr" field.
+func (m *CheckMutation) SetStderr(s string) {
+	m.stderr = &s
+}
+
+// Stderr returns the value of the "stderr" field in the mutation.
+func (m *CheckMutation) Stderr() (r string, exists bool) {
+	v := m.stderr
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStderr returns the old "stderr" field's value of the Check entity.
+// If the Check object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *CheckMutation) OldStderr(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStderr is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStderr requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStderr: %w", err)
+	}
+	return oldValue.Stderr, nil
+}
+
+// ClearStderr clears the value of the "stderr" field.
+func (m *CheckMutation) ClearStderr() {
+	m.stderr = nil
+	m.clearedFields[check.FieldStderr] = struct{}{}
+}
+
+// StderrCleared returns if the "stderr" field was cleared in this mutation.
+func (m *CheckMutation) StderrCleared() bool {
+	_, ok := m.clearedFields[check.FieldStderr]
+	return ok
+}
+
+// ResetStderr resets all changes to the "stderr" field.
+func (m *CheckMutation) ResetStderr() {
+	m.stderr = nil
+	delete(m.clearedFields, check.FieldStderr)
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (m *CheckMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
@@ -513,7 +757,7 @@ func (m *CheckMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *CheckMutation) Fields() []string {
-	fields := make([]string, 0, 7)
+	fields := make([]string, 0, 11)
 	if m.website != nil {
 		fields = append(fields, check.FieldWebsiteID)
 	}
@@ -523,6 +767,9 @@ func (m *CheckMutation) Fields() []string {
 	if m.has_error != nil {
 		fields = append(fields, check.FieldHasError)
 	}
+	if m.status != nil {
+		fields = append(fields, check.FieldStatus)
+	}
 	if m.error_message != nil {
 		fields = append(fields, check.FieldErrorMessage)
 	}
@@ -532,6 +779,15 @@ func (m *CheckMutation) Fields() []string {
 	if m.diff_change != nil {
 		fields = append(fields, check.FieldDiffChange)
 	}
+	if m.value != nil {
+		fields = append(fields, check.FieldValue)
+	}
+	if m.exit_code != nil {
+		fields = append(fields, check.FieldExitCode)
+	}
+	if m.stderr != nil {
+		fields = append(fields, check.FieldStderr)
+	}
 	if m.created_at != nil {
 		fields = append(fields, check.FieldCreatedAt)
 	}
@@ -549,12 +805,20 @@ func (m *CheckMutation) Field(name string) (ent.Value, bool) {
 		return m.Result()
 	case check.FieldHasError:
 		return m.HasError()
+	case check.FieldStatus:
+		return m.Status()
 	case check.FieldErrorMessage:
 		return m.ErrorMessage()
 	case check.FieldHasDiff:
 		return m.HasDiff()
 	case check.FieldDiffChange:
 		return m.DiffChange()
+	case check.FieldValue:
+		return m.Value()
+	case check.FieldExitCode:
+		return m.ExitCode()
+	case check.FieldStderr:
+		return m.Stderr()
 	case check.FieldCreatedAt:
 		return m.CreatedAt()
 	}
@@ -572,12 +836,20 @@ func (m *CheckMutation) OldField(ctx context.Context, name string) (ent.Value, e
 		return m.OldResult(ctx)
 	case check.FieldHasError:
 		return m.OldHasError(ctx)
+	case check.FieldStatus:
+		return m.OldStatus(ctx)
 	case check.FieldErrorMessage:
 		return m.OldErrorMessage(ctx)
 	case check.FieldHasDiff:
 		return m.OldHasDiff(ctx)
 	case check.FieldDiffChange:
 		return m.OldDiffChange(ctx)
+	case check.FieldValue:
+		return m.OldValue(ctx)
+	case check.FieldExitCode:
+		return m.OldExitCode(ctx)
+	case check.FieldStderr:
+		return m.OldStderr(ctx)
 	case check.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	}
@@ -610,6 +882,13 @@ func (m *CheckMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetHasError(v)
 		return nil
+	case check.FieldStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
 	case check.FieldErrorMessage:
 		v, ok := value.(string)
 		if !ok {
@@ -631,6 +910,27 @@ func (m *CheckMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetDiffChange(v)
 		return nil
+	case check.FieldValue:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetValue(v)
+		return nil
+	case check.FieldExitCode:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExitCode(v)
+		return nil
+	case check.FieldStderr:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStderr(v)
+		return nil
 	case check.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
@@ -645,13 +945,26 @@ func (m *CheckMutation) SetField(name string, value ent.Value) error {
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
 func (m *CheckMutation) AddedFields() []string {
-	return nil
+	var fields []string
+	if m.addvalue != nil {
+		fields = append(fields, check.FieldValue)
+	}
+	if m.addexit_code != nil {
+		fields = append(fields, check.FieldExitCode)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
 func (m *CheckMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case check.FieldValue:
+		return m.AddedValue()
+	case check.FieldExitCode:
+		return m.AddedExitCode()
+	}
 	return nil, false
 }
 
@@ -660,6 +973,20 @@ func (m *CheckMutation) AddedField(name string) (ent.Value, bool) {
 // type.
 func (m *CheckMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case check.FieldValue:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddValue(v)
+		return nil
+	case check.FieldExitCode:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddExitCode(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Check numeric field %s", name)
 }
@@ -671,12 +998,24 @@ func (m *CheckMutation) ClearedFields() []string {
 	if m.FieldCleared(check.FieldWebsiteID) {
 		fields = append(fields, check.FieldWebsiteID)
 	}
+	if m.FieldCleared(check.FieldResult) {
+		fields = append(fields, check.FieldResult)
+	}
 	if m.FieldCleared(check.FieldErrorMessage) {
 		fields = append(fields, check.FieldErrorMessage)
 	}
 	if m.FieldCleared(check.FieldDiffChange) {
 		fields = append(fields, check.FieldDiffChange)
 	}
+	if m.FieldCleared(check.FieldValue) {
+		fields = append(fields, check.FieldValue)
+	}
+	if m.FieldCleared(check.FieldExitCode) {
+		fields = append(fields, check.FieldExitCode)
+	}
+	if m.FieldCleared(check.FieldStderr) {
+		fields = append(fields, check.FieldStderr)
+	}
 	return fields
 }
 
@@ -694,12 +1033,24 @@ func (m *CheckMutation) ClearField(name string) error {
 	case check.FieldWebsiteID:
 		m.ClearWebsiteID()
 		return nil
+	case check.FieldResult:
+		m.ClearResult()
+		return nil
 	case check.FieldErrorMessage:
 		m.ClearErrorMessage()
 		return nil
 	case check.FieldDiffChange:
 		m.ClearDiffChange()
 		return nil
+	case check.FieldValue:
+		m.ClearValue()
+		return nil
+	case check.FieldExitCode:
+		m.ClearExitCode()
+		return nil
+	case check.FieldStderr:
+		m.ClearStderr()
+		return nil
 	}
 	return fmt.Errorf("unknown Check nullable field %s", name)
 }
@@ -717,6 +1068,9 @@ func (m *CheckMutation) ResetField(name string) error {
 	case check.FieldHasError:
 		m.ResetHasError()
 		return nil
+	case check.FieldStatus:
+		m.ResetStatus()
+		return nil
 	case check.FieldErrorMessage:
 		m.ResetErrorMessage()
 		return nil
@@ -726,6 +1080,15 @@ func (m *CheckMutation) ResetField(name string) error {
 	case check.FieldDiffChange:
 		m.ResetDiffChange()
 		return nil
+	case check.FieldValue:
+		m.ResetValue()
+		return nil
+	case check.FieldExitCode:
+		m.ResetExitCode()
+		return nil
+	case check.FieldStderr:
+		m.ResetStderr()
+		return nil
 	case check.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
@@ -19,16 +19,16 @@ import (
 func init() {
 	checkFields := schema.Check{}.Fields()
 	_ = checkFields
-	// checkDescResult is the schema descriptor for result field.
-	checkDescResult := checkFields[2].Descriptor()
-	// check.ResultValidator is a validator for the "result" field. It is called by the builders before save.
-	check.ResultValidator = checkDescResult.Validators[0].(func([]byte) error)
 	// checkDescHasError is the schema descriptor for has_error field.
 	checkDescHasError := checkFields[3].Descriptor()
 	// check.DefaultHasError holds the default value on creation for the has_error field.
 	check.DefaultHasError = checkDescHasError.Default.(bool)
+	// checkDescStatus is the schema descriptor for status field.
+	checkDescStatus := checkFields[4].Descriptor()
+	// check.DefaultStatus holds the default value on creation for the status field.
+	check.DefaultStatus = checkDescStatus.Default.(string)
 	// checkDescHasDiff is the schema descriptor for has_diff field.
-	checkDescHasDiff := checkFields[5].Descriptor()
+	checkDescHasDiff := checkFields[6].Descriptor()
 	// check.DefaultHasDiff holds the default value on creation for the has_diff field.
 	check.DefaultHasDiff = checkDescHasDiff.Default.(bool)
 	// checkDescID is the schema descriptor for id field.
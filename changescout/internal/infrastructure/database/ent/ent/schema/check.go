@@ -18,11 +18,17 @@ func (Check) Fields() []ent.Field {
 	return []ent.Field{
 		field.UUID("id", uuid.UUID{}).Default(uuid.New),
 		field.UUID("website_id", uuid.UUID{}).Optional(),
-		field.Bytes("result").NotEmpty(),
+		// Result is empty for the checks which failed before producing content.
+		field.Bytes("result").Optional(),
 		field.Bool("has_error").Default(false),
+		field.String("status").Default("ok"),
 		field.String("error_message").Optional(),
 		field.Bool("has_diff").Default(false),
 		field.JSON("diff_change", &diff.Result{}).Optional(),
+		// Value is the number extracted by the numeric setting of the website.
+		field.Float("value").Optional().Nillable(),
+		field.Int("exit_code").Optional().Nillable(),
+		field.Text("stderr").Optional(),
 		field.Time("created_at"),
 	}
 }
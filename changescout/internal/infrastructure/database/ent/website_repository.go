@@ -148,14 +148,18 @@ func (w WebsiteRepository) UpdateStatusWebsite(ctx context.Context, siteID uuid.
 
 func modeDomainToEnt(mode domain.Mode) (string, error) {
 	switch mode {
-	case domain.ModePlain:
-		return string(domain.ModePlain), nil
+	case domain.ModePlain, domain.ModeExec, domain.ModeCrawl, domain.ModeWorkflow:
+		return string(mode), nil
 	default:
 		return "", database.ErrModeNotCorrect
 	}
 }
 
 func entToWebsite(website *ent.Website) domain.Website {
+	setting := transform.ToValueOrDefault(website.Setting, domain.Setting{})
+	// Settings saved before the processing pipeline are migrated on read
+	setting.Pipeline = setting.ProcessingSteps()
+
 	return domain.Website{
 		ID:          website.ID,
 		Name:        website.Name,
@@ -163,7 +167,7 @@ func entToWebsite(website *ent.Website) domain.Website {
 		Enabled:     website.Enabled,
 		Cron:        website.Cron,
 		Mode:        domain.Mode(website.Mode),
-		Setting:     transform.ToValueOrDefault(website.Setting, domain.Setting{}),
+		Setting:     setting,
 		UserID:      website.UserID,
 		CreatedAt:   website.CreatedAt,
 		UpdatedAt:   website.UpdatedAt,
@@ -330,6 +330,49 @@ func (s *WebsiteRepositoryTestSuite) TestUpdateWebsite() {
 	}
 }
 
+func (s *WebsiteRepositoryTestSuite) TestUpdateWebsiteModes() {
+	modes := []domain.Mode{domain.ModePlain, domain.ModeExec, domain.ModeCrawl, domain.ModeWorkflow}
+
+	for _, mode := range modes {
+		s.Run(string(mode), func() {
+			created, err := s.repo.CreateWebsite(s.ctx, domain.Website{
+				Name:    "Website " + string(mode),
+				URL:     "https://example.com/" + string(mode),
+				Enabled: true,
+				Mode:    mode,
+				UserID:  s.userID,
+				Cron:    "*/15 * * * *",
+			})
+			s.Require().NoError(err)
+
+			lastCheckAt := time.Now()
+			updated, err := s.repo.UpdateWebsite(s.ctx, domain.Website{
+				ID:          created.ID,
+				Name:        "Updated " + string(mode),
+				Mode:        mode,
+				LastCheckAt: &lastCheckAt,
+			})
+			s.Require().NoError(err)
+			assert.Equal(s.T(), mode, updated.Mode)
+			assert.Equal(s.T(), "Updated "+string(mode), updated.Name)
+		})
+	}
+}
+
+func (s *WebsiteRepositoryTestSuite) TestUpdateWebsiteUnknownMode() {
+	created, err := s.repo.CreateWebsite(s.ctx, domain.Website{
+		Name:   "Test Website",
+		URL:    "https://example.com",
+		Mode:   domain.ModePlain,
+		UserID: s.userID,
+		Cron:   "*/15 * * * *",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.repo.UpdateWebsite(s.ctx, domain.Website{ID: created.ID, Mode: "unknown"})
+	assert.ErrorIs(s.T(), err, database.ErrModeNotCorrect)
+}
+
 func (s *WebsiteRepositoryTestSuite) TestListWebsites() {
 	// Create test websites
 	websites := []domain.Website{
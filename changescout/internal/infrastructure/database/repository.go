@@ -38,6 +38,7 @@ type CheckRepository interface {
 	ClearChecksByWebsite(ctx context.Context, websiteID uuid.UUID) error
 	ListChecks(ctx context.Context, filters CheckFilters, pagination domain.Pagination) ([]domain.Check, int, error)
 	UpdateCheck(ctx context.Context, check domain.Check) (domain.Check, error)
+	GetSeries(ctx context.Context, filters CheckFilters) ([]domain.SeriesPoint, error)
 }
 
 type NotificationRepository interface {
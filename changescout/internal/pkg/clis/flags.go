@@ -79,4 +79,52 @@ var (
 		flags.WithDefaultValue[bool](false),
 		flags.WithEnvVars[bool]("CS_BROWSER_DISABLE"),
 		flags.WithUsage[bool]("Disable the browser"))
+
+	FlagsExecEnabled = flags.NewBoolFlag("exec-enabled",
+		flags.WithCategory[bool]("exec"),
+		flags.WithDefaultValue[bool](false),
+		flags.WithEnvVars[bool]("CS_EXEC_ENABLED"),
+		flags.WithUsage[bool]("Enable the exec mode to monitor output of local commands"))
+
+	FlagsExecAllowedCommands = flags.NewStringFlag("exec-allowed-commands",
+		flags.WithCategory[string]("exec"),
+		flags.WithDefaultValue[string](""),
+		flags.WithEnvVars[string]("CS_EXEC_ALLOWED_COMMANDS"),
+		flags.WithUsage[string]("Comma separated list of commands allowed in the exec mode"))
+
+	FlagsExecAllowedEnv = flags.NewStringFlag("exec-allowed-env",
+		flags.WithCategory[string]("exec"),
+		flags.WithDefaultValue[string](""),
+		flags.WithEnvVars[string]("CS_EXEC_ALLOWED_ENV"),
+		flags.WithUsage[string]("Comma separated list of environment variables passed to the commands and settable by websites"))
+
+	FlagsExecMaxTimeout = flags.NewDurationFlag("exec-max-timeout",
+		flags.WithDefaultValue[time.Duration](time.Minute*5),
+		flags.WithCategory[time.Duration]("exec"),
+		flags.WithEnvVars[time.Duration]("CS_EXEC_MAX_TIMEOUT"),
+		flags.WithUsage[time.Duration]("The maximum timeout of the commands, it caps the timeout of the websites"))
+
+	FlagsPreviewRateLimit = flags.NewIntFlag("preview-rate-limit",
+		flags.WithCategory[int]("preview"),
+		flags.WithDefaultValue[int](10),
+		flags.WithEnvVars[int]("CS_PREVIEW_RATE_LIMIT"),
+		flags.WithUsage[int]("Maximum previews per user and minute, 0 disables the limit"))
+
+	FlagsPluginsDir = flags.NewStringFlag("plugins-dir",
+		flags.WithCategory[string]("plugins"),
+		flags.WithDefaultValue[string](""),
+		flags.WithEnvVars[string]("CS_PLUGINS_DIR"),
+		flags.WithUsage[string]("Directory of the WebAssembly processor plugins, a plugin is selected by its file name"))
+
+	FlagsPluginsMemoryLimit = flags.NewIntFlag("plugins-memory-limit",
+		flags.WithCategory[int]("plugins"),
+		flags.WithDefaultValue[int](64),
+		flags.WithEnvVars[int]("CS_PLUGINS_MEMORY_LIMIT"),
+		flags.WithUsage[int]("Maximum memory of a plugin call in megabytes"))
+
+	FlagsPluginsTimeout = flags.NewDurationFlag("plugins-timeout",
+		flags.WithCategory[time.Duration]("plugins"),
+		flags.WithDefaultValue[time.Duration](time.Second*2),
+		flags.WithEnvVars[time.Duration]("CS_PLUGINS_TIMEOUT"),
+		flags.WithUsage[time.Duration]("Maximum duration of a plugin call"))
 )
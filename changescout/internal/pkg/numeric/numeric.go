@@ -0,0 +1,206 @@
+package numeric
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/oliveagle/jsonpath"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNoNumber       = errors.New("no number found")
+	ErrUnknownLocale  = errors.New("unknown locale")
+	ErrInvalidNumeric = errors.New("invalid numeric setting")
+)
+
+const defaultLocale = "en"
+
+var (
+	commaDecimalDotted = format{decimal: ",", groups: "."}
+	// The space groups are the plain, the no-break and the narrow no-break spaces
+	commaDecimalSpaced = format{decimal: ",", groups: " \u00a0\u202f"}
+)
+
+// format is the decimal separator and the possible group separators of a locale.
+type format struct {
+	decimal string
+	groups  string
+}
+
+// formats are looked up by the full locale first and by the language then.
+var formats = map[string]format{
+	"en":    {decimal: ".", groups: ","},
+	"ja":    {decimal: ".", groups: ","},
+	"zh":    {decimal: ".", groups: ","},
+	"ko":    {decimal: ".", groups: ","},
+	"hi":    {decimal: ".", groups: ","},
+	"de-ch": {decimal: ".", groups: "'’"},
+	"de":    commaDecimalDotted,
+	"es":    commaDecimalDotted,
+	"it":    commaDecimalDotted,
+	"pt":    commaDecimalDotted,
+	"nl":    commaDecimalDotted,
+	"da":    commaDecimalDotted,
+	"tr":    commaDecimalDotted,
+	"id":    commaDecimalDotted,
+	"el":    commaDecimalDotted,
+	"fr":    commaDecimalSpaced,
+	"ru":    commaDecimalSpaced,
+	"uk":    commaDecimalSpaced,
+	"pl":    commaDecimalSpaced,
+	"cs":    commaDecimalSpaced,
+	"sk":    commaDecimalSpaced,
+	"sv":    commaDecimalSpaced,
+	"fi":    commaDecimalSpaced,
+	"nb":    commaDecimalSpaced,
+	"hu":    commaDecimalSpaced,
+	"bg":    commaDecimalSpaced,
+}
+
+func lookup(locale string) (format, error) {
+	if locale == "" {
+		locale = defaultLocale
+	}
+
+	tag := strings.ReplaceAll(strings.ToLower(locale), "_", "-")
+	if f, ok := formats[tag]; ok {
+		return f, nil
+	}
+	language, _, _ := strings.Cut(tag, "-")
+	if f, ok := formats[language]; ok {
+		return f, nil
+	}
+	return format{}, fmt.Errorf("%w: %s", ErrUnknownLocale, locale)
+}
+
+// pattern matches a grouped number, e.g. 1,234.5, or a plain one, e.g. 1234.5.
+func (f format) pattern() *regexp.Regexp {
+	decimal := regexp.QuoteMeta(f.decimal)
+	groups := "[" + regexp.QuoteMeta(f.groups) + "]"
+	return regexp.MustCompile(fmt.Sprintf(`[-+]?(?:\d{1,3}(?:%[2]s\d{3})+|\d+)(?:%[1]s\d+)?`, decimal, groups))
+}
+
+// Parse returns the first number of the text in the format of the locale, e.g. "Price: 1.299,99 €" in "de".
+func Parse(text, locale string) (float64, error) {
+	f, err := lookup(locale)
+	if err != nil {
+		return 0, err
+	}
+
+	match := f.pattern().FindString(strings.ReplaceAll(text, "−", "-"))
+	if match == "" {
+		return 0, ErrNoNumber
+	}
+
+	for _, group := range f.groups {
+		match = strings.ReplaceAll(match, string(group), "")
+	}
+	match = strings.Replace(match, f.decimal, ".", 1)
+
+	value, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrNoNumber, err)
+	}
+	return value, nil
+}
+
+// Validate checks the source, the locale and the conditions of the numeric setting.
+func Validate(conf domain.NumericOption) error {
+	if !conf.Enabled {
+		return nil
+	}
+
+	sources := 0
+	for _, source := range []string{conf.Selector, conf.JSONPath, conf.Regex} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("%w: only one of selector, JSONPath or regex can be set", ErrInvalidNumeric)
+	}
+	if conf.Regex != "" {
+		if err := processors.ValidateRegex(domain.RegexOption{Patterns: []string{conf.Regex}}); err != nil {
+			return err
+		}
+	}
+	if _, err := lookup(conf.Locale); err != nil {
+		return err
+	}
+	if conf.DeltaPercent != nil && *conf.DeltaPercent <= 0 {
+		return fmt.Errorf("%w: delta percent must be positive", ErrInvalidNumeric)
+	}
+	return nil
+}
+
+// Extract selects the number of the content with the source of the setting and parses it.
+func Extract(body []byte, conf domain.NumericOption) (float64, error) {
+	if conf.JSONPath != "" {
+		return extractJSONPath(body, conf)
+	}
+
+	var processor processors.Processor
+	switch {
+	case conf.Selector != "":
+		processor = processors.NewHTMLProcessor(domain.Setting{Selectors: []string{conf.Selector}})
+	case conf.Regex != "":
+		processor = processors.NewRegexProcessor(domain.Setting{Regex: domain.RegexOption{Patterns: []string{conf.Regex}}})
+	}
+
+	if processor != nil {
+		selected, err := processors.New(processor).Run(body)
+		if err != nil {
+			return 0, err
+		}
+		body = selected
+	}
+
+	return Parse(string(body), conf.Locale)
+}
+
+// extractJSONPath looks up the JSONPath in the document and parses only the returned scalar,
+// so digits of the path itself never count as the value.
+func extractJSONPath(body []byte, conf domain.NumericOption) (float64, error) {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return 0, fmt.Errorf("%w: %v", processors.ErrInvalidContent, err)
+	}
+
+	value, err := jsonpath.JsonPathLookup(data, conf.JSONPath)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", processors.ErrNoMatches, conf.JSONPath)
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return Parse(v, conf.Locale)
+	default:
+		return 0, fmt.Errorf("%w: %s is not a number or a string", ErrNoNumber, conf.JSONPath)
+	}
+}
+
+// Fires reports whether the value crossed a threshold or moved by more than the delta from the previous value.
+// The thresholds fire for the first value when it is already beyond them.
+func Fires(conf domain.NumericOption, previous *float64, current float64) bool {
+	if conf.Above != nil && current > *conf.Above && (previous == nil || *previous <= *conf.Above) {
+		return true
+	}
+	if conf.Below != nil && current < *conf.Below && (previous == nil || *previous >= *conf.Below) {
+		return true
+	}
+	if conf.DeltaPercent != nil && previous != nil && current != *previous {
+		if *previous == 0 {
+			return true
+		}
+		return math.Abs(current-*previous)/math.Abs(*previous)*100 > *conf.DeltaPercent
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package numeric_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/numeric"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/gelleson/changescout/changescout/internal/utils/transform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		locale   string
+		expected float64
+		wantErr  error
+	}{
+		{name: "Default locale", text: "Price: $1,299.99", expected: 1299.99},
+		{name: "English", text: "1,234,567", locale: "en-US", expected: 1234567},
+		{name: "English decimal", text: "1.234", locale: "en", expected: 1.234},
+		{name: "German", text: "Preis: 1.299,99 €", locale: "de", expected: 1299.99},
+		{name: "German without groups", text: "1299,5", locale: "de_DE", expected: 1299.5},
+		{name: "Swiss German", text: "CHF 1'299.90", locale: "de-CH", expected: 1299.9},
+		{name: "French", text: "1 299,99 €", locale: "fr", expected: 1299.99},
+		{name: "Negative", text: "change: -4.5%", expected: -4.5},
+		{name: "Minus sign", text: "−12", expected: -12},
+		{name: "First number", text: "3 items for 10", expected: 3},
+		{name: "No number", text: "Sold out", wantErr: numeric.ErrNoNumber},
+		{name: "Unknown locale", text: "1", locale: "xx", wantErr: numeric.ErrUnknownLocale},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := numeric.Parse(test.text, test.locale)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, test.expected, value, 1e-9)
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		conf     domain.NumericOption
+		expected float64
+		wantErr  error
+	}{
+		{
+			name:     "Selector",
+			body:     `<html><body><span class="old">12,00 €</span><span class="price">9,99 €</span></body></html>`,
+			conf:     domain.NumericOption{Enabled: true, Selector: ".price", Locale: "de"},
+			expected: 9.99,
+		},
+		{
+			name:     "JSONPath",
+			body:     `{"product":{"price":"1,299.00"}}`,
+			conf:     domain.NumericOption{Enabled: true, JSONPath: "$.product.price"},
+			expected: 1299,
+		},
+		{
+			name:     "JSONPath with an index",
+			body:     `{"offers":[{"price":19.99}]}`,
+			conf:     domain.NumericOption{Enabled: true, JSONPath: "$.offers[0].price"},
+			expected: 19.99,
+		},
+		{
+			name:     "JSONPath with a digit in the key",
+			body:     `{"v2":{"price":7.5}}`,
+			conf:     domain.NumericOption{Enabled: true, JSONPath: "$.v2.price"},
+			expected: 7.5,
+		},
+		{
+			name:    "JSONPath to an object",
+			body:    `{"product":{"price":1}}`,
+			conf:    domain.NumericOption{Enabled: true, JSONPath: "$.product"},
+			wantErr: numeric.ErrNoNumber,
+		},
+		{
+			name:    "JSONPath matches nothing",
+			body:    `{"product":{}}`,
+			conf:    domain.NumericOption{Enabled: true, JSONPath: "$.product.price"},
+			wantErr: processors.ErrNoMatches,
+		},
+		{
+			name:     "Regex",
+			body:     "Stock: 12 left, price 30",
+			conf:     domain.NumericOption{Enabled: true, Regex: `price (\d+)`},
+			expected: 30,
+		},
+		{
+			name:     "Whole content",
+			body:     "42",
+			conf:     domain.NumericOption{Enabled: true},
+			expected: 42,
+		},
+		{
+			name:    "Selector matches nothing",
+			body:    `<html><body><p>redesigned</p></body></html>`,
+			conf:    domain.NumericOption{Enabled: true, Selector: ".price"},
+			wantErr: processors.ErrNoMatches,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := numeric.Extract([]byte(test.body), test.conf)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, test.expected, value, 1e-9)
+		})
+	}
+}
+
+func TestFires(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     domain.NumericOption
+		previous *float64
+		current  float64
+		fires    bool
+	}{
+		{name: "Crosses above", conf: domain.NumericOption{Above: transform.ToPtr(100.0)}, previous: transform.ToPtr(90.0), current: 110, fires: true},
+		{name: "Stays above", conf: domain.NumericOption{Above: transform.ToPtr(100.0)}, previous: transform.ToPtr(105.0), current: 110, fires: false},
+		{name: "Crosses below", conf: domain.NumericOption{Below: transform.ToPtr(50.0)}, previous: transform.ToPtr(55.0), current: 45, fires: true},
+		{name: "Stays below", conf: domain.NumericOption{Below: transform.ToPtr(50.0)}, previous: transform.ToPtr(45.0), current: 40, fires: false},
+		{name: "First value beyond threshold", conf: domain.NumericOption{Below: transform.ToPtr(50.0)}, current: 45, fires: true},
+		{name: "Delta exceeded", conf: domain.NumericOption{DeltaPercent: transform.ToPtr(10.0)}, previous: transform.ToPtr(100.0), current: 89, fires: true},
+		{name: "Delta not exceeded", conf: domain.NumericOption{DeltaPercent: transform.ToPtr(10.0)}, previous: transform.ToPtr(100.0), current: 109, fires: false},
+		{name: "Delta from zero", conf: domain.NumericOption{DeltaPercent: transform.ToPtr(10.0)}, previous: transform.ToPtr(0.0), current: 1, fires: true},
+		{name: "Delta of the first value", conf: domain.NumericOption{DeltaPercent: transform.ToPtr(10.0)}, current: 1, fires: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.fires, numeric.Fires(test.conf, test.previous, test.current))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, numeric.Validate(domain.NumericOption{}))
+	assert.NoError(t, numeric.Validate(domain.NumericOption{Enabled: true, Selector: ".price", Locale: "de-AT", DeltaPercent: transform.ToPtr(5.0)}))
+	assert.ErrorIs(t, numeric.Validate(domain.NumericOption{Enabled: true, Selector: ".price", Regex: `\d+`}), numeric.ErrInvalidNumeric)
+	assert.ErrorIs(t, numeric.Validate(domain.NumericOption{Enabled: true, Locale: "xx"}), numeric.ErrUnknownLocale)
+	assert.ErrorIs(t, numeric.Validate(domain.NumericOption{Enabled: true, DeltaPercent: transform.ToPtr(0.0)}), numeric.ErrInvalidNumeric)
+	assert.Error(t, numeric.Validate(domain.NumericOption{Enabled: true, Regex: "("}))
+}
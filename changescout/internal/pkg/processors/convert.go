@@ -0,0 +1,161 @@
+package processors
+
+import (
+	"bytes"
+	"fmt"
+	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/JohannesKaufmann/html-to-markdown/plugin"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/go-shiori/go-readability"
+	"golang.org/x/net/html"
+	"regexp"
+	"strings"
+)
+
+var (
+	whitespace = regexp.MustCompile(`[ \t\r\n\f]+`)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// blockElements are rendered on their own lines in the plain text format.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "dd": true, "div": true,
+	"dl": true, "dt": true, "fieldset": true, "figcaption": true, "figure": true, "footer": true,
+	"form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "main": true, "nav": true, "ol": true, "p": true, "pre": true,
+	"section": true, "table": true, "ul": true,
+}
+
+// skippedElements never contain readable text.
+var skippedElements = map[string]bool{
+	"head": true, "noscript": true, "script": true, "style": true, "template": true, "svg": true,
+}
+
+type ConvertProcessor struct {
+	conf domain.Setting
+}
+
+func NewConvertProcessor(conf domain.Setting) *ConvertProcessor {
+	return &ConvertProcessor{
+		conf: conf,
+	}
+}
+
+func (p *ConvertProcessor) Skip() bool {
+	return p.conf.Convert.Format == ""
+}
+
+func (p *ConvertProcessor) Process(body []byte) ([]byte, error) {
+	if p.Skip() {
+		return body, nil
+	}
+
+	if p.conf.Convert.Readability {
+		body = mainContent(body)
+	}
+
+	switch p.conf.Convert.Format {
+	case domain.ConvertFormatMarkdown:
+		converter := md.NewConverter("", true, nil)
+		converter.Use(plugin.GitHubFlavored())
+		converter.Remove("head")
+		markdown, err := converter.ConvertBytes(body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidContent, err)
+		}
+		return markdown, nil
+	case domain.ConvertFormatText:
+		doc, err := html.Parse(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidContent, err)
+		}
+		var builder strings.Builder
+		writeText(&builder, doc)
+		return []byte(cleanText(builder.String())), nil
+	default:
+		return body, nil
+	}
+}
+
+// mainContent isolates the article of the page, the body is returned as is when none is found.
+func mainContent(body []byte) []byte {
+	article, err := readability.FromReader(bytes.NewReader(body), nil)
+	if err != nil || strings.TrimSpace(article.Content) == "" {
+		return body
+	}
+	return []byte(article.Content)
+}
+
+func writeText(builder *strings.Builder, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		builder.WriteString(whitespace.ReplaceAllString(node.Data, " "))
+		return
+	case html.ElementNode:
+		if skippedElements[node.Data] {
+			return
+		}
+	}
+
+	switch node.Data {
+	case "br":
+		builder.WriteString("\n")
+		return
+	case "li":
+		builder.WriteString("\n- ")
+		writeChildren(builder, node)
+		return
+	case "tr":
+		var cells []string
+		for cell := node.FirstChild; cell != nil; cell = cell.NextSibling {
+			if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+				var text strings.Builder
+				writeChildren(&text, cell)
+				cells = append(cells, strings.TrimSpace(whitespace.ReplaceAllString(text.String(), " ")))
+			}
+		}
+		builder.WriteString("\n" + strings.Join(cells, " | "))
+		return
+	case "a":
+		var text strings.Builder
+		writeChildren(&text, node)
+		builder.WriteString(text.String())
+		if href := attribute(node, "href"); href != "" && strings.TrimSpace(text.String()) != href {
+			builder.WriteString(" (" + href + ")")
+		}
+		return
+	}
+
+	block := node.Type == html.ElementNode && blockElements[node.Data]
+	if block {
+		builder.WriteString("\n\n")
+	}
+	writeChildren(builder, node)
+	if block {
+		builder.WriteString("\n\n")
+	}
+}
+
+func writeChildren(builder *strings.Builder, node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeText(builder, child)
+	}
+}
+
+func attribute(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// cleanText trims every line and collapses the blank lines between blocks.
+func cleanText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
@@ -0,0 +1,113 @@
+package processors_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertProcessor_Process(t *testing.T) {
+	input := []byte(`
+		<html>
+			<head><title>Changelog</title><style>h1 { color: red; }</style></head>
+			<body>
+				<h1>Release   1.2</h1>
+				<p>See the <a href="https://example.com/docs">docs</a>.</p>
+				<ul><li>Faster checks</li><li>New <b>API</b></li></ul>
+				<table>
+					<tr><th>Plan</th><th>Price</th></tr>
+					<tr><td>Basic</td><td>10</td></tr>
+				</table>
+			</body>
+		</html>
+	`)
+
+	tests := []struct {
+		name     string
+		conf     domain.ConvertOption
+		expected string
+	}{
+		{
+			name: "Markdown",
+			conf: domain.ConvertOption{Format: domain.ConvertFormatMarkdown},
+			expected: strings.Join([]string{
+				"# Release 1.2",
+				"",
+				"See the [docs](https://example.com/docs).",
+				"",
+				"- Faster checks",
+				"- New **API**",
+				"",
+				"| Plan | Price |",
+				"| --- | --- |",
+				"| Basic | 10 |",
+			}, "\n"),
+		},
+		{
+			name: "Plain text",
+			conf: domain.ConvertOption{Format: domain.ConvertFormatText},
+			expected: strings.Join([]string{
+				"Release 1.2",
+				"",
+				"See the docs (https://example.com/docs).",
+				"",
+				"- Faster checks",
+				"- New API",
+				"",
+				"Plan | Price",
+				"Basic | 10",
+			}, "\n"),
+		},
+		{
+			name:     "Disabled",
+			conf:     domain.ConvertOption{},
+			expected: string(input),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewConvertProcessor(domain.Setting{Convert: test.conf})
+			actual, err := p.Process(input)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestConvertProcessor_Readability(t *testing.T) {
+	paragraph := strings.Repeat("The new release brings faster checks and better notifications. ", 10)
+	input := []byte(`
+		<html><body>
+			<nav><a href="/">Home</a><a href="/pricing">Pricing</a></nav>
+			<article><h2>Release notes</h2><p>` + paragraph + `</p><p>` + paragraph + `</p></article>
+			<footer>Copyright</footer>
+		</body></html>
+	`)
+
+	p := processors.NewConvertProcessor(domain.Setting{
+		Convert: domain.ConvertOption{Format: domain.ConvertFormatText, Readability: true},
+	})
+	processed, err := p.Process(input)
+	assert.NoError(t, err)
+	output := string(processed)
+
+	assert.Contains(t, output, "The new release brings faster checks")
+	assert.NotContains(t, output, "Pricing")
+	assert.NotContains(t, output, "Copyright")
+}
+
+func TestHTMLProcessor_ProcessKeepsMarkupForConversion(t *testing.T) {
+	p := processors.NewHTMLProcessor(domain.Setting{
+		Selectors: []string{"main", "a::attr(href)"},
+		Convert:   domain.ConvertOption{Format: domain.ConvertFormatMarkdown},
+	})
+
+	output, err := p.Process([]byte(`<html><body><main><h1>Title</h1><a href="/more">More</a></main></body></html>`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "<main><h1>Title</h1><a href=\"/more\">More</a></main>\n/more", string(output))
+}
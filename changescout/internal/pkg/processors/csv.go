@@ -0,0 +1,137 @@
+package processors
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"regexp"
+	"unicode/utf8"
+)
+
+var ErrInvalidCSVDelimiter = errors.New("csv delimiter must be a single character")
+
+type CSVProcessor struct {
+	conf domain.Setting
+}
+
+func NewCSVProcessor(conf domain.Setting) *CSVProcessor {
+	return &CSVProcessor{
+		conf: conf,
+	}
+}
+
+// ValidateCSV checks the delimiter and the filter patterns.
+func ValidateCSV(conf domain.CSVOption) error {
+	if conf.Delimiter != "" && utf8.RuneCountInString(conf.Delimiter) != 1 {
+		return ErrInvalidCSVDelimiter
+	}
+	for _, filter := range conf.Filters {
+		if _, err := regexp.Compile(filter.Pattern); err != nil {
+			return fmt.Errorf("invalid csv filter %q: %w", filter.Pattern, err)
+		}
+	}
+	return nil
+}
+
+func (p *CSVProcessor) Skip() bool {
+	return p.conf.CSV.IsZero()
+}
+
+func (p *CSVProcessor) Process(body []byte) ([]byte, error) {
+	if p.Skip() {
+		return body, nil
+	}
+
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if p.conf.CSV.Delimiter != "" {
+		reader.Comma, _ = utf8.DecodeRuneInString(p.conf.CSV.Delimiter)
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidContent, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: empty csv document", ErrInvalidContent)
+	}
+
+	header, rows := records[0], records[1:]
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+
+	rows = p.filter(rows, index)
+
+	columns := make([]int, 0, len(header))
+	if len(p.conf.CSV.Columns) == 0 {
+		for i := range header {
+			columns = append(columns, i)
+		}
+	}
+	for _, name := range p.conf.CSV.Columns {
+		if i, ok := index[name]; ok {
+			columns = append(columns, i)
+		}
+	}
+
+	selectedHeader := project(header, columns)
+	selectedRows := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		selectedRows = append(selectedRows, project(row, columns))
+	}
+
+	sortRows(selectedHeader, selectedRows, p.conf.CSV.KeyColumns)
+
+	return tableCSV(selectedHeader, selectedRows), nil
+}
+
+func (p *CSVProcessor) filter(rows [][]string, index map[string]int) [][]string {
+	type filter struct {
+		column  int
+		pattern *regexp.Regexp
+	}
+
+	var filters []filter
+	for _, f := range p.conf.CSV.Filters {
+		column, ok := index[f.Column]
+		pattern, err := regexp.Compile(f.Pattern)
+		if !ok || err != nil {
+			// Filters on unknown columns match nothing
+			return [][]string{}
+		}
+		filters = append(filters, filter{column: column, pattern: pattern})
+	}
+
+	result := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		keep := true
+		for _, f := range filters {
+			if f.column >= len(row) || !f.pattern.MatchString(row[f.column]) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+// project returns the cells of the columns, missing cells are empty.
+func project(row []string, columns []int) []string {
+	result := make([]string, len(columns))
+	for i, column := range columns {
+		if column < len(row) {
+			result[i] = row[column]
+		}
+	}
+	return result
+}
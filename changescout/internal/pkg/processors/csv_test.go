@@ -0,0 +1,72 @@
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVProcessor_Process(t *testing.T) {
+	input := []byte("id,name,status,updated\n3,gamma,active,2024-01-03\n1,alpha,active,2024-01-01\n2,beta,retired,2024-01-02\n")
+
+	tests := []struct {
+		name     string
+		conf     domain.CSVOption
+		input    []byte
+		expected string
+	}{
+		{
+			name:     "Select columns",
+			conf:     domain.CSVOption{Columns: []string{"name", "id", "unknown"}},
+			input:    input,
+			expected: "name,id\ngamma,3\nalpha,1\nbeta,2",
+		},
+		{
+			name:     "Filter rows",
+			conf:     domain.CSVOption{Filters: []domain.CSVFilter{{Column: "status", Pattern: "^active$"}}},
+			input:    input,
+			expected: "id,name,status,updated\n3,gamma,active,2024-01-03\n1,alpha,active,2024-01-01",
+		},
+		{
+			name:     "Sort by key columns",
+			conf:     domain.CSVOption{Columns: []string{"id", "name"}, KeyColumns: []string{"id"}},
+			input:    input,
+			expected: "id,name\n1,alpha\n2,beta\n3,gamma",
+		},
+		{
+			name:     "Custom delimiter",
+			conf:     domain.CSVOption{Delimiter: ";", KeyColumns: []string{"b"}},
+			input:    []byte("a;b\nx;2\ny;1"),
+			expected: "a,b\ny,1\nx,2",
+		},
+		{
+			name:     "Filter on unknown column",
+			conf:     domain.CSVOption{Filters: []domain.CSVFilter{{Column: "missing", Pattern: "."}}},
+			input:    input,
+			expected: "id,name,status,updated",
+		},
+		{
+			name:     "Disabled",
+			conf:     domain.CSVOption{},
+			input:    input,
+			expected: string(input),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewCSVProcessor(domain.Setting{CSV: test.conf})
+			actual, err := p.Process(test.input)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestValidateCSV(t *testing.T) {
+	assert.NoError(t, processors.ValidateCSV(domain.CSVOption{Delimiter: "\t"}))
+	assert.ErrorIs(t, processors.ValidateCSV(domain.CSVOption{Delimiter: "::"}), processors.ErrInvalidCSVDelimiter)
+	assert.Error(t, processors.ValidateCSV(domain.CSVOption{Filters: []domain.CSVFilter{{Column: "a", Pattern: "("}}}))
+}
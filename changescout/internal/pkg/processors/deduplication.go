@@ -1,10 +1,18 @@
 package processors
 
 import (
-	"bytes"
+	"errors"
+	"fmt"
 	"github.com/gelleson/changescout/changescout/internal/domain"
+	"regexp"
+	"strings"
 )
 
+var ErrInvalidDeduplicationMode = errors.New("invalid deduplication mode")
+
+// sentenceEnd splits the text after the sentence terminators followed by whitespace.
+var sentenceEnd = regexp.MustCompile(`[.!?]+\s+`)
+
 type DeduplicationProcessor struct {
 	conf domain.Setting
 }
@@ -15,58 +23,89 @@ func NewDeduplicationProcessor(conf domain.Setting) *DeduplicationProcessor {
 	}
 }
 
+// ValidateDeduplication checks the mode and the delimiter of the delimiter mode.
+func ValidateDeduplication(conf domain.DeduplicationOption) error {
+	switch conf.Mode {
+	case "", domain.DeduplicationModeLine, domain.DeduplicationModeSentence:
+		return nil
+	case domain.DeduplicationModeDelimiter:
+		if conf.Delimiter == "" {
+			return fmt.Errorf("%w: delimiter is required", ErrInvalidDeduplicationMode)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidDeduplicationMode, conf.Mode)
+	}
+}
+
 func (p *DeduplicationProcessor) Skip() bool {
 	return !p.conf.Deduplication
 }
 
-func (p *DeduplicationProcessor) Process(body []byte) []byte {
+// Process keeps the first occurrence of every line, sentence or token, the empty ones are dropped.
+func (p *DeduplicationProcessor) Process(body []byte) ([]byte, error) {
 	// If deduplication is disabled, return the original body
 	if p.Skip() {
-		return body
+		return body, nil
 	}
 
 	// If body is empty, return empty slice (not nil)
 	if len(body) == 0 {
-		return []byte{}
+		return []byte{}, nil
+	}
+
+	conf := p.conf.DeduplicationOption
+	if err := ValidateDeduplication(conf); err != nil {
+		return nil, err
 	}
 
-	// Create a set to store unique lines and a slice to maintain order
-	uniqueLines := make(map[string]struct{})
-	var orderedUniqueLines []string
+	tokens, separator, terminator := tokenize(string(body), conf)
 
-	// For input without newlines, split into individual words
-	if !bytes.Contains(body, []byte{'\n'}) {
-		// Split the input string every 5 characters (e.g., "line1", "line2")
-		input := string(body)
-		for i := 0; i < len(input); i += 5 {
-			if i+5 <= len(input) {
-				word := input[i : i+5]
-				if _, exists := uniqueLines[word]; !exists {
-					uniqueLines[word] = struct{}{}
-					orderedUniqueLines = append(orderedUniqueLines, word)
-				}
-			}
+	seen := make(map[string]struct{})
+	var unique []string
+	for _, token := range tokens {
+		if strings.TrimSpace(token) == "" {
+			continue
 		}
-	} else {
-		// Split by newlines and process
-		lines := bytes.Split(body, []byte{'\n'})
-		for _, line := range lines {
-			if len(line) > 0 {
-				lineStr := string(line)
-				if _, exists := uniqueLines[lineStr]; !exists {
-					uniqueLines[lineStr] = struct{}{}
-					orderedUniqueLines = append(orderedUniqueLines, lineStr)
-				}
-			}
+		key := dedupeKey(token, conf)
+		if _, exists := seen[key]; exists {
+			continue
 		}
+		seen[key] = struct{}{}
+		unique = append(unique, token)
 	}
 
-	// Build output with newlines
-	var output []byte
-	for _, line := range orderedUniqueLines {
-		output = append(output, []byte(line)...)
-		output = append(output, '\n')
+	if len(unique) == 0 {
+		return []byte{}, nil
 	}
+	return []byte(strings.Join(unique, separator) + terminator), nil
+}
 
-	return output
+// tokenize splits the content by the mode and returns the separator and the terminator of the output.
+func tokenize(body string, conf domain.DeduplicationOption) (tokens []string, separator, terminator string) {
+	switch conf.Mode {
+	case domain.DeduplicationModeSentence:
+		var sentences []string
+		start := 0
+		for _, loc := range sentenceEnd.FindAllStringIndex(body, -1) {
+			sentences = append(sentences, strings.TrimSpace(body[start:loc[1]]))
+			start = loc[1]
+		}
+		sentences = append(sentences, strings.TrimSpace(body[start:]))
+		return sentences, " ", ""
+	case domain.DeduplicationModeDelimiter:
+		return strings.Split(body, conf.Delimiter), conf.Delimiter, ""
+	default:
+		return strings.Split(body, "\n"), "\n", "\n"
+	}
+}
+
+func dedupeKey(token string, conf domain.DeduplicationOption) string {
+	if conf.NormalizeWhitespace {
+		token = strings.Join(strings.Fields(token), " ")
+	}
+	if conf.IgnoreCase {
+		token = strings.ToLower(token)
+	}
+	return token
 }
@@ -45,19 +45,102 @@ func TestDeduplicationProcessor_Process(t *testing.T) {
 				Deduplication: true,
 			},
 			input:    []byte("line1line2line1line3line2"),
-			expected: []byte("line1\nline2\nline3\n"),
+			expected: []byte("line1line2line1line3line2\n"),
+		},
+		{
+			name: "Ignore case",
+			conf: domain.Setting{
+				Deduplication: true,
+				DeduplicationOption: domain.DeduplicationOption{
+					IgnoreCase: true,
+				},
+			},
+			input:    []byte("Line1\nline1\nLINE2\nline2\n"),
+			expected: []byte("Line1\nLINE2\n"),
+		},
+		{
+			name: "Normalize whitespace",
+			conf: domain.Setting{
+				Deduplication: true,
+				DeduplicationOption: domain.DeduplicationOption{
+					NormalizeWhitespace: true,
+				},
+			},
+			input:    []byte("a  b\n a b \na\tb\nc\n"),
+			expected: []byte("a  b\nc\n"),
+		},
+		{
+			name: "Sentence mode",
+			conf: domain.Setting{
+				Deduplication: true,
+				DeduplicationOption: domain.DeduplicationOption{
+					Mode: domain.DeduplicationModeSentence,
+				},
+			},
+			input:    []byte("Sale today! Prices are low.\nSale today! Come in?"),
+			expected: []byte("Sale today! Prices are low. Come in?"),
+		},
+		{
+			name: "Delimiter mode",
+			conf: domain.Setting{
+				Deduplication: true,
+				DeduplicationOption: domain.DeduplicationOption{
+					Mode:       domain.DeduplicationModeDelimiter,
+					Delimiter:  ",",
+					IgnoreCase: true,
+				},
+			},
+			input:    []byte("a,b,A,c,,b"),
+			expected: []byte("a,b,c"),
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			p := processors.NewDeduplicationProcessor(test.conf)
-			actual := p.Process(test.input)
+			actual, err := p.Process(test.input)
+			assert.NoError(t, err)
 			assert.Equal(t, test.expected, actual)
 		})
 	}
 }
 
+func TestDeduplicationProcessor_InvalidOption(t *testing.T) {
+	p := processors.NewDeduplicationProcessor(domain.Setting{
+		Deduplication: true,
+		DeduplicationOption: domain.DeduplicationOption{
+			Mode: domain.DeduplicationModeDelimiter,
+		},
+	})
+	_, err := p.Process([]byte("a,b"))
+	assert.ErrorIs(t, err, processors.ErrInvalidDeduplicationMode)
+}
+
+func TestValidateDeduplication(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    domain.DeduplicationOption
+		wantErr bool
+	}{
+		{name: "Default", conf: domain.DeduplicationOption{}},
+		{name: "Sentence", conf: domain.DeduplicationOption{Mode: domain.DeduplicationModeSentence}},
+		{name: "Delimiter", conf: domain.DeduplicationOption{Mode: domain.DeduplicationModeDelimiter, Delimiter: ";"}},
+		{name: "Missing delimiter", conf: domain.DeduplicationOption{Mode: domain.DeduplicationModeDelimiter}, wantErr: true},
+		{name: "Unknown mode", conf: domain.DeduplicationOption{Mode: "word"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := processors.ValidateDeduplication(test.conf)
+			if test.wantErr {
+				assert.ErrorIs(t, err, processors.ErrInvalidDeduplicationMode)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestDeduplicationProcessor_Skip(t *testing.T) {
 	tests := []struct {
 		name     string
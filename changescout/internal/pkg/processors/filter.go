@@ -0,0 +1,96 @@
+package processors
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"regexp"
+)
+
+type FilterProcessor struct {
+	conf    domain.Setting
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+	err     error
+}
+
+func NewFilterProcessor(conf domain.Setting) *FilterProcessor {
+	p := &FilterProcessor{
+		conf: conf,
+	}
+	p.include, p.err = compileFilter(conf.Filter, conf.Filter.Include)
+	if p.err == nil {
+		p.exclude, p.err = compileFilter(conf.Filter, conf.Filter.Exclude)
+	}
+	return p
+}
+
+// ValidateFilter checks the patterns of the line filter.
+func ValidateFilter(conf domain.FilterOption) error {
+	if _, err := compileFilter(conf, conf.Include); err != nil {
+		return err
+	}
+	_, err := compileFilter(conf, conf.Exclude)
+	return err
+}
+
+// compileFilter turns the keywords or the regular expressions into patterns matching a line.
+func compileFilter(conf domain.FilterOption, patterns []string) ([]*regexp.Regexp, error) {
+	result := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		expr := pattern
+		if !conf.Regex {
+			expr = regexp.QuoteMeta(pattern)
+		}
+		if conf.IgnoreCase {
+			expr = "(?i)" + expr
+		}
+
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter pattern %q: %w", pattern, err)
+		}
+		result = append(result, re)
+	}
+	return result, nil
+}
+
+func (p *FilterProcessor) Skip() bool {
+	return p.conf.Filter.IsZero()
+}
+
+// Process keeps the matching lines in order, the content is empty when no line is kept.
+func (p *FilterProcessor) Process(body []byte) ([]byte, error) {
+	if p.Skip() {
+		return body, nil
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	lines := bytes.Split(body, []byte("\n"))
+	kept := make([][]byte, 0, len(lines))
+	for _, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if len(p.include) > 0 && !matchAny(p.include, line) {
+			continue
+		}
+		if matchAny(p.exclude, line) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	return bytes.Join(kept, []byte("\n")), nil
+}
+
+func matchAny(patterns []*regexp.Regexp, line []byte) bool {
+	for _, re := range patterns {
+		if re.Match(line) {
+			return true
+		}
+	}
+	return false
+}
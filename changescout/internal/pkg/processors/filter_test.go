@@ -0,0 +1,81 @@
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterProcessor_Process(t *testing.T) {
+	input := []byte("Widget Pro in stock\nAdvertisement: buy now\n\nGadget sold out\nwidget mini in stock (advertisement)\n")
+
+	tests := []struct {
+		name     string
+		conf     domain.FilterOption
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Include keywords",
+			conf:     domain.FilterOption{Include: []string{"Widget", "Gadget"}},
+			expected: "Widget Pro in stock\nGadget sold out",
+		},
+		{
+			name:     "Exclude keywords",
+			conf:     domain.FilterOption{Exclude: []string{"Advertisement"}},
+			expected: "Widget Pro in stock\nGadget sold out\nwidget mini in stock (advertisement)",
+		},
+		{
+			name:     "Ignore case",
+			conf:     domain.FilterOption{Include: []string{"widget"}, Exclude: []string{"advertisement"}, IgnoreCase: true},
+			expected: "Widget Pro in stock",
+		},
+		{
+			name:     "Keywords are not patterns",
+			conf:     domain.FilterOption{Include: []string{"(advertisement)"}},
+			expected: "widget mini in stock (advertisement)",
+		},
+		{
+			name:     "Regex",
+			conf:     domain.FilterOption{Include: []string{`^\w+ (Pro|mini)\b`}, Regex: true},
+			expected: "Widget Pro in stock\nwidget mini in stock (advertisement)",
+		},
+		{
+			name:     "No lines kept",
+			conf:     domain.FilterOption{Include: []string{"Gizmo"}},
+			expected: "",
+		},
+		{
+			name:     "Without patterns",
+			conf:     domain.FilterOption{},
+			expected: string(input),
+		},
+		{
+			name:    "Invalid regex",
+			conf:    domain.FilterOption{Exclude: []string{`(`}, Regex: true},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewFilterProcessor(domain.Setting{Filter: test.conf})
+			actual, err := p.Process(input)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestValidateFilter(t *testing.T) {
+	assert.NoError(t, processors.ValidateFilter(domain.FilterOption{Include: []string{"("}}))
+	assert.NoError(t, processors.ValidateFilter(domain.FilterOption{Include: []string{`\d+`}, Regex: true}))
+	assert.Error(t, processors.ValidateFilter(domain.FilterOption{Include: []string{"("}, Regex: true}))
+	assert.Error(t, processors.ValidateFilter(domain.FilterOption{Exclude: []string{"["}, Regex: true}))
+}
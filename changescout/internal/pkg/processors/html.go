@@ -2,10 +2,16 @@ package processors
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gelleson/changescout/changescout/internal/domain"
+	"regexp"
+	"strings"
 )
 
+var attrSelector = regexp.MustCompile(`^(.*?)::attr\(\s*([^)\s]+)\s*\)$`)
+
 type HTMLProcessor struct {
 	conf domain.Setting
 }
@@ -17,26 +23,86 @@ func NewHTMLProcessor(conf domain.Setting) *HTMLProcessor {
 }
 
 func (p *HTMLProcessor) Skip() bool {
-	return p.conf.Selectors == nil
+	return len(p.conf.Selectors) == 0 && p.conf.Records.RowSelector == ""
 }
 
-func (p *HTMLProcessor) Process(body []byte) []byte {
+func (p *HTMLProcessor) Process(body []byte) ([]byte, error) {
 	if p.Skip() {
-		return body
+		return body, nil
 	}
 
-	var stringBuilder bytes.Buffer
-
 	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
 	if err != nil {
-		return body
+		return nil, fmt.Errorf("%w: %v", ErrInvalidContent, err)
 	}
 
+	if p.conf.Records.RowSelector != "" {
+		return p.records(doc)
+	}
+
+	var matches []string
 	for _, selector := range p.conf.Selectors {
-		doc.Find(selector).Each(func(i int, s *goquery.Selection) {
-			stringBuilder.WriteString(s.Text())
+		css, attr := parseSelector(selector)
+		doc.Find(css).Each(func(i int, s *goquery.Selection) {
+			if attr == "" && p.conf.Convert.Format != "" {
+				// Keep the markup for the conversion processor
+				if html, err := goquery.OuterHtml(s); err == nil {
+					matches = append(matches, html)
+				}
+				return
+			}
+			if value, ok := selectionValue(s, attr); ok {
+				matches = append(matches, value)
+			}
 		})
 	}
 
-	return stringBuilder.Bytes()
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatches, strings.Join(p.conf.Selectors, ", "))
+	}
+
+	return []byte(strings.Join(matches, "\n")), nil
+}
+
+// records renders every row as an object of its fields.
+func (p *HTMLProcessor) records(doc *goquery.Document) ([]byte, error) {
+	records := make([]map[string]string, 0)
+	doc.Find(p.conf.Records.RowSelector).Each(func(i int, row *goquery.Selection) {
+		record := make(map[string]string, len(p.conf.Records.Fields))
+		for _, field := range p.conf.Records.Fields {
+			css, attr := parseSelector(field.Selector)
+			selection := row
+			if css != "" {
+				selection = row.Find(css).First()
+			}
+			value, _ := selectionValue(selection, attr)
+			record[field.Name] = strings.TrimSpace(value)
+		}
+		records = append(records, record)
+	})
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatches, p.conf.Records.RowSelector)
+	}
+
+	return json.Marshal(records)
+}
+
+// parseSelector splits the ::attr(name) suffix from the CSS selector.
+func parseSelector(selector string) (css string, attr string) {
+	match := attrSelector.FindStringSubmatch(strings.TrimSpace(selector))
+	if match == nil {
+		return selector, ""
+	}
+	return strings.TrimSpace(match[1]), match[2]
+}
+
+func selectionValue(s *goquery.Selection, attr string) (string, bool) {
+	if s.Length() == 0 {
+		return "", false
+	}
+	if attr != "" {
+		return s.Attr(attr)
+	}
+	return s.Text(), true
 }
@@ -14,6 +14,7 @@ func TestHTMLProcessor_Process(t *testing.T) {
 		conf     domain.Setting
 		input    []byte
 		expected []byte
+		wantErr  error
 	}{
 		{
 			name: "With selectors",
@@ -29,7 +30,51 @@ func TestHTMLProcessor_Process(t *testing.T) {
 					</body>
 				</html>
 			`),
-			expected: []byte("Heading 1Paragraph 1Paragraph 2"),
+			expected: []byte("Heading 1\nParagraph 1\nParagraph 2"),
+		},
+		{
+			name: "With attribute selectors",
+			conf: domain.Setting{
+				Selectors: []string{"a.product::attr(href)", "img::attr( data-src )"},
+			},
+			input: []byte(`
+				<html>
+					<body>
+						<a class="product" href="/p/1">First</a>
+						<a class="product" href="/p/2">Second</a>
+						<a class="product">No link</a>
+						<img data-src="/img/1.png">
+					</body>
+				</html>
+			`),
+			expected: []byte("/p/1\n/p/2\n/img/1.png"),
+		},
+		{
+			name: "With records",
+			conf: domain.Setting{
+				Selectors: []string{"h1"},
+				Records: domain.RecordOption{
+					RowSelector: "li.product",
+					Fields: []domain.RecordField{
+						{Name: "name", Selector: ".name"},
+						{Name: "price", Selector: ".price"},
+						{Name: "url", Selector: "a::attr(href)"},
+						{Name: "sku", Selector: "::attr(data-sku)"},
+					},
+				},
+			},
+			input: []byte(`
+				<html>
+					<body>
+						<h1>Products</h1>
+						<ul>
+							<li class="product" data-sku="A1"><a href="/p/1"><span class="name"> First </span></a><span class="price">10</span></li>
+							<li class="product" data-sku="B2"><a href="/p/2"><span class="name">Second</span></a></li>
+						</ul>
+					</body>
+				</html>
+			`),
+			expected: []byte(`[{"name":"First","price":"10","sku":"A1","url":"/p/1"},{"name":"Second","price":"","sku":"B2","url":"/p/2"}]`),
 		},
 		{
 			name: "Without selectors",
@@ -55,19 +100,46 @@ func TestHTMLProcessor_Process(t *testing.T) {
 				</html>
 			`),
 		},
+		{
+			name:     "Empty selectors",
+			conf:     domain.Setting{Selectors: []string{}},
+			input:    []byte(`<html><body><p>Paragraph</p></body></html>`),
+			expected: []byte(`<html><body><p>Paragraph</p></body></html>`),
+		},
 		{
 			name:     "Invalid HTML",
 			conf:     domain.Setting{},
 			input:    []byte("This is not valid HTML"),
 			expected: []byte("This is not valid HTML"),
 		},
+		{
+			name: "Selector without matches",
+			conf: domain.Setting{
+				Selectors: []string{"h1", ".price"},
+			},
+			input:   []byte(`<html><body><p>Redesigned page</p></body></html>`),
+			wantErr: processors.ErrNoMatches,
+		},
+		{
+			name: "Records without rows",
+			conf: domain.Setting{
+				Records: domain.RecordOption{RowSelector: "li.product"},
+			},
+			input:   []byte(`<html><body><p>Redesigned page</p></body></html>`),
+			wantErr: processors.ErrNoMatches,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			p := processors.NewHTMLProcessor(test.conf)
-			actual := p.Process(test.input)
-			assert.Equal(t, test.expected, actual)
+			actual, err := p.Process(test.input)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, string(test.expected), string(actual))
 		})
 	}
 }
@@ -84,6 +156,13 @@ func TestHTMLProcessor_Skip(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "With records",
+			conf: domain.Setting{
+				Records: domain.RecordOption{RowSelector: "li"},
+			},
+			expected: false,
+		},
 		{
 			name: "Without selectors",
 			conf: domain.Setting{
@@ -91,6 +170,13 @@ func TestHTMLProcessor_Skip(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "With empty selectors",
+			conf: domain.Setting{
+				Selectors: []string{},
+			},
+			expected: true,
+		},
 	}
 
 	for _, test := range tests {
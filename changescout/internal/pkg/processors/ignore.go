@@ -0,0 +1,150 @@
+package processors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"regexp"
+)
+
+var ErrUnknownIgnorePreset = errors.New("unknown ignore preset")
+
+var ignorePresets = map[domain.IgnorePreset]domain.IgnoreRule{
+	domain.IgnorePresetISODates: {
+		Pattern:     `\d{4}-\d{2}-\d{2}(?:[T ]\d{2}:\d{2}(?::\d{2}(?:\.\d+)?)?(?:Z|[+-]\d{2}:?\d{2})?)?`,
+		Replacement: "[date]",
+	},
+	domain.IgnorePresetUUIDs: {
+		Pattern:     `(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`,
+		Replacement: "[uuid]",
+	},
+	domain.IgnorePresetHexHashes: {
+		Pattern:     `(?i)\b[0-9a-f]{32,}\b`,
+		Replacement: "[hash]",
+	},
+	domain.IgnorePresetRelativeTimes: {
+		Pattern:     `(?i)\b(?:(?:\d+|an?|one)\s+(?:second|minute|hour|day|week|month|year)s?\s+ago|just now|yesterday)\b`,
+		Replacement: "[time]",
+	},
+}
+
+// IgnoreElementsProcessor removes the ignored elements from the HTML before the extraction.
+type IgnoreElementsProcessor struct {
+	conf domain.Setting
+}
+
+func NewIgnoreElementsProcessor(conf domain.Setting) *IgnoreElementsProcessor {
+	return &IgnoreElementsProcessor{
+		conf: conf,
+	}
+}
+
+func (p *IgnoreElementsProcessor) Skip() bool {
+	return len(p.conf.Ignore.Selectors) == 0
+}
+
+func (p *IgnoreElementsProcessor) Process(body []byte) ([]byte, error) {
+	if p.Skip() {
+		return body, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidContent, err)
+	}
+
+	// Ignored elements are optional, so no matches is not an error
+	removed := 0
+	for _, selector := range p.conf.Ignore.Selectors {
+		selection := doc.Find(selector)
+		removed += selection.Length()
+		selection.Remove()
+	}
+
+	// Keep the body untouched when nothing matched, so non-HTML content is not wrapped into a document.
+	if removed == 0 {
+		return body, nil
+	}
+
+	html, err := doc.Html()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(html), nil
+}
+
+// MaskProcessor replaces volatile values with stable placeholders.
+type MaskProcessor struct {
+	conf  domain.Setting
+	rules []maskRule
+	err   error
+}
+
+type maskRule struct {
+	pattern     *regexp.Regexp
+	replacement []byte
+}
+
+func NewMaskProcessor(conf domain.Setting) *MaskProcessor {
+	p := &MaskProcessor{
+		conf: conf,
+	}
+
+	for _, rule := range ignoreRules(conf.Ignore) {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			p.err = fmt.Errorf("invalid ignore rule %q: %w", rule.Pattern, err)
+			break
+		}
+		p.rules = append(p.rules, maskRule{pattern: re, replacement: []byte(rule.Replacement)})
+	}
+
+	return p
+}
+
+func (p *MaskProcessor) Skip() bool {
+	return len(p.conf.Ignore.Rules) == 0 && len(p.conf.Ignore.Presets) == 0
+}
+
+func (p *MaskProcessor) Process(body []byte) ([]byte, error) {
+	if p.Skip() {
+		return body, nil
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	for _, rule := range p.rules {
+		body = rule.pattern.ReplaceAll(body, rule.replacement)
+	}
+	return body, nil
+}
+
+// ValidateIgnore checks the rules and presets of the ignore setting.
+func ValidateIgnore(conf domain.IgnoreOption) error {
+	for _, preset := range conf.Presets {
+		if _, ok := ignorePresets[preset]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownIgnorePreset, preset)
+		}
+	}
+
+	for _, rule := range conf.Rules {
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("invalid ignore rule %q: %w", rule.Pattern, err)
+		}
+	}
+	return nil
+}
+
+// ignoreRules returns the presets followed by the custom rules.
+func ignoreRules(conf domain.IgnoreOption) []domain.IgnoreRule {
+	rules := make([]domain.IgnoreRule, 0, len(conf.Presets)+len(conf.Rules))
+	for _, preset := range conf.Presets {
+		if rule, ok := ignorePresets[preset]; ok {
+			rules = append(rules, rule)
+		}
+	}
+	return append(rules, conf.Rules...)
+}
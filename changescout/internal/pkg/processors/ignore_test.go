@@ -0,0 +1,124 @@
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoreElementsProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     domain.IgnoreOption
+		input    string
+		expected string
+	}{
+		{
+			name:     "Remove elements",
+			conf:     domain.IgnoreOption{Selectors: []string{".ad", "#views"}},
+			input:    `<html><head></head><body><p>News</p><div class="ad">Buy now</div><span id="views">42</span></body></html>`,
+			expected: `<html><head></head><body><p>News</p></body></html>`,
+		},
+		{
+			name:     "Nothing matched",
+			conf:     domain.IgnoreOption{Selectors: []string{".ad"}},
+			input:    `{"news":"value"}`,
+			expected: `{"news":"value"}`,
+		},
+		{
+			name:     "Without selectors",
+			conf:     domain.IgnoreOption{},
+			input:    `<p>News</p>`,
+			expected: `<p>News</p>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewIgnoreElementsProcessor(domain.Setting{Ignore: test.conf})
+			actual, err := p.Process([]byte(test.input))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestMaskProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     domain.IgnoreOption
+		input    string
+		expected string
+	}{
+		{
+			name:     "ISO dates",
+			conf:     domain.IgnoreOption{Presets: []domain.IgnorePreset{domain.IgnorePresetISODates}},
+			input:    "Updated 2024-05-01T10:20:30Z, built 2024-05-02",
+			expected: "Updated [date], built [date]",
+		},
+		{
+			name:     "UUIDs",
+			conf:     domain.IgnoreOption{Presets: []domain.IgnorePreset{domain.IgnorePresetUUIDs}},
+			input:    "session 3F2504E0-4F89-11D3-9A0C-0305E82C3301",
+			expected: "session [uuid]",
+		},
+		{
+			name:     "Hex hashes",
+			conf:     domain.IgnoreOption{Presets: []domain.IgnorePreset{domain.IgnorePresetHexHashes}},
+			input:    "app.d41d8cd98f00b204e9800998ecf8427e.js",
+			expected: "app.[hash].js",
+		},
+		{
+			name:     "Relative times",
+			conf:     domain.IgnoreOption{Presets: []domain.IgnorePreset{domain.IgnorePresetRelativeTimes}},
+			input:    "Posted 5 minutes ago, edited an hour ago, viewed just now",
+			expected: "Posted [time], edited [time], viewed [time]",
+		},
+		{
+			name:     "Custom rule",
+			conf:     domain.IgnoreOption{Rules: []domain.IgnoreRule{{Pattern: `csrf=(\w+)`, Replacement: "csrf=[token]"}}},
+			input:    `<form action="/login?csrf=abc123">`,
+			expected: `<form action="/login?csrf=[token]">`,
+		},
+		{
+			name:     "Without rules",
+			conf:     domain.IgnoreOption{},
+			input:    "Posted 5 minutes ago",
+			expected: "Posted 5 minutes ago",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewMaskProcessor(domain.Setting{Ignore: test.conf})
+			actual, err := p.Process([]byte(test.input))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestMaskProcessor_ProcessInvalidRule(t *testing.T) {
+	p := processors.NewMaskProcessor(domain.Setting{
+		Ignore: domain.IgnoreOption{Rules: []domain.IgnoreRule{{Pattern: `(`}}},
+	})
+
+	_, err := p.Process([]byte("text"))
+
+	assert.Error(t, err)
+}
+
+func TestValidateIgnore(t *testing.T) {
+	assert.NoError(t, processors.ValidateIgnore(domain.IgnoreOption{
+		Presets: []domain.IgnorePreset{domain.IgnorePresetUUIDs},
+		Rules:   []domain.IgnoreRule{{Pattern: `\d+`}},
+	}))
+	assert.ErrorIs(t, processors.ValidateIgnore(domain.IgnoreOption{
+		Presets: []domain.IgnorePreset{"emails"},
+	}), processors.ErrUnknownIgnorePreset)
+	assert.Error(t, processors.ValidateIgnore(domain.IgnoreOption{
+		Rules: []domain.IgnoreRule{{Pattern: `(`}},
+	}))
+}
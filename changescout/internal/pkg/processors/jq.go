@@ -0,0 +1,113 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/itchyny/gojq"
+	"time"
+)
+
+const (
+	// jqTimeout stops expressions which never terminate, e.g. `repeat(.)`.
+	jqTimeout = 5 * time.Second
+	// jqMaxResults bounds the values collected from expressions which emit a lot of them, e.g. `range(1e9)`.
+	jqMaxResults = 10_000
+)
+
+var ErrInvalidJQ = errors.New("invalid jq expression")
+
+type JQProcessor struct {
+	conf domain.Setting
+	code *gojq.Code
+	err  error
+}
+
+func NewJQProcessor(conf domain.Setting) *JQProcessor {
+	p := &JQProcessor{
+		conf: conf,
+	}
+	if !p.Skip() {
+		p.code, p.err = compileJQ(conf.JQ)
+	}
+	return p
+}
+
+// ValidateJQ checks the jq expression compiles.
+func ValidateJQ(expr string) error {
+	if expr == "" {
+		return nil
+	}
+	_, err := compileJQ(expr)
+	return err
+}
+
+func (p *JQProcessor) Skip() bool {
+	return p.conf.JQ == ""
+}
+
+// Process runs the jq expression and writes every emitted value as indented JSON.
+// An expression which emits no value is ErrNoMatches, like a selector which matches nothing.
+func (p *JQProcessor) Process(body []byte) ([]byte, error) {
+	if p.Skip() {
+		return body, nil
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	var input interface{}
+	if err := json.Unmarshal(body, &input); err != nil {
+		return nil, fmt.Errorf("%w: content is not JSON: %v", ErrInvalidContent, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), jqTimeout)
+	defer cancel()
+
+	var results [][]byte
+	iter := p.code.RunWithContext(ctx, input)
+	for {
+		value, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := value.(error); ok {
+			var halt *gojq.HaltError
+			if errors.As(err, &halt) && halt.Value() == nil {
+				break
+			}
+			return nil, fmt.Errorf("%w: %v", ErrInvalidJQ, err)
+		}
+
+		if len(results) == jqMaxResults {
+			return nil, fmt.Errorf("%w: more than %d results", ErrInvalidJQ, jqMaxResults)
+		}
+
+		output, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidJQ, err)
+		}
+		results = append(results, output)
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatches, p.conf.JQ)
+	}
+
+	return bytes.Join(results, []byte("\n")), nil
+}
+
+func compileJQ(expr string) (*gojq.Code, error) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJQ, err)
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJQ, err)
+	}
+	return code, nil
+}
@@ -0,0 +1,95 @@
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJQProcessor_Process(t *testing.T) {
+	input := []byte(`{"items":[{"name":"b","price":20,"stock":0},{"name":"a","price":10,"stock":5}]}`)
+
+	tests := []struct {
+		name     string
+		expr     string
+		input    []byte
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "Filter and project",
+			expr:     `[.items[] | select(.stock > 0) | .name]`,
+			input:    input,
+			expected: "[\n  \"a\"\n]",
+		},
+		{
+			name:     "Sort and reshape",
+			expr:     `.items | sort_by(.name) | map({(.name): .price}) | add`,
+			input:    input,
+			expected: "{\n  \"a\": 10,\n  \"b\": 20\n}",
+		},
+		{
+			name:     "Multiple outputs",
+			expr:     `.items[].price`,
+			input:    input,
+			expected: "20\n10",
+		},
+		{
+			name:    "Runtime error",
+			expr:    `.items.name`,
+			input:   input,
+			wantErr: processors.ErrInvalidJQ,
+		},
+		{
+			name:    "No results",
+			expr:    `.items[] | select(.price > 100)`,
+			input:   input,
+			wantErr: processors.ErrNoMatches,
+		},
+		{
+			name:    "Too many results",
+			expr:    `range(20000)`,
+			input:   input,
+			wantErr: processors.ErrInvalidJQ,
+		},
+		{
+			name:    "Invalid JSON",
+			expr:    `.items`,
+			input:   []byte("not json"),
+			wantErr: processors.ErrInvalidContent,
+		},
+		{
+			name:    "Compile error",
+			expr:    `.items[`,
+			input:   input,
+			wantErr: processors.ErrInvalidJQ,
+		},
+		{
+			name:     "Without expression",
+			input:    input,
+			expected: string(input),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewJQProcessor(domain.Setting{JQ: test.expr})
+			actual, err := p.Process(test.input)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestValidateJQ(t *testing.T) {
+	assert.NoError(t, processors.ValidateJQ(""))
+	assert.NoError(t, processors.ValidateJQ(`.items | length`))
+	assert.ErrorIs(t, processors.ValidateJQ(`.items |`), processors.ErrInvalidJQ)
+	assert.ErrorIs(t, processors.ValidateJQ(`undefined_function(1)`), processors.ErrInvalidJQ)
+}
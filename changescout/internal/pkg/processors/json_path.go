@@ -2,8 +2,10 @@ package processors
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/gelleson/changescout/changescout/internal/domain"
 	"github.com/oliveagle/jsonpath"
+	"strings"
 )
 
 type JSONPathProcessor struct {
@@ -20,21 +22,21 @@ func (p *JSONPathProcessor) Skip() bool {
 	return len(p.conf.JSONPath) == 0
 }
 
-func (p *JSONPathProcessor) Process(body []byte) []byte {
+func (p *JSONPathProcessor) Process(body []byte) ([]byte, error) {
 	// If JSONPath is empty, return the original body
 	if p.Skip() {
-		return body
+		return body, nil
 	}
 
 	// If the body is empty, return an empty slice
 	if len(body) == 0 {
-		return []byte{}
+		return []byte{}, nil
 	}
 
 	// Parse the JSON input first
 	var jsonData interface{}
 	if err := json.Unmarshal(body, &jsonData); err != nil {
-		return body // Return original body if JSON is invalid
+		return nil, fmt.Errorf("%w: %v", ErrInvalidContent, err)
 	}
 
 	// Use a map to store the evaluated JSONPath results
@@ -51,20 +53,10 @@ func (p *JSONPathProcessor) Process(body []byte) []byte {
 		}
 	}
 
-	// If we found valid paths, return the result
-	if foundValidPath {
-		output, err := json.Marshal(result)
-		if err != nil {
-			return []byte(`{}`)
-		}
-		return output
-	}
-
-	// If no valid paths were found and we have JSONPaths configured
-	if len(p.conf.JSONPath) > 0 {
-		return []byte(`{}`)
+	// None of the paths matched, the document structure has likely changed
+	if !foundValidPath {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatches, strings.Join(p.conf.JSONPath, ", "))
 	}
 
-	// If no JSONPaths were configured, return the original JSON
-	return body
+	return json.Marshal(result)
 }
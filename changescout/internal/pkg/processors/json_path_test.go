@@ -15,6 +15,7 @@ func TestJSONPathProcessor_Process(t *testing.T) {
 		conf     domain.Setting
 		input    string
 		expected string
+		wantErr  error
 	}{
 		{
 			name: "With valid JSONPath",
@@ -84,7 +85,15 @@ func TestJSONPathProcessor_Process(t *testing.T) {
 					]
 				}
 			}`,
-			expected: `{}`,
+			wantErr: processors.ErrNoMatches,
+		},
+		{
+			name: "With invalid JSON",
+			conf: domain.Setting{
+				JSONPath: []string{"$.store"},
+			},
+			input:   `<html></html>`,
+			wantErr: processors.ErrInvalidContent,
 		},
 		{
 			name: "With empty input",
@@ -132,7 +141,9 @@ func TestJSONPathProcessor_Process(t *testing.T) {
 
 			// Convert input string to bytes
 			var input []byte
-			if test.input != "" {
+			if test.wantErr != nil {
+				input = []byte(test.input)
+			} else if test.input != "" {
 				// Normalize the JSON formatting
 				var tmp interface{}
 				err := json.Unmarshal([]byte(test.input), &tmp)
@@ -142,7 +153,12 @@ func TestJSONPathProcessor_Process(t *testing.T) {
 			}
 
 			// Get actual result
-			actual := p.Process(input)
+			actual, err := p.Process(input)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
 
 			// For non-empty expected results, normalize the JSON formatting
 			var expectedBytes []byte
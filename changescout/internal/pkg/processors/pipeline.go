@@ -0,0 +1,114 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+)
+
+var ErrUnknownStep = errors.New("unknown processing step")
+
+var stepProcessors = map[domain.StepType]func(domain.Setting) Processor{
+	domain.StepRemove:      func(conf domain.Setting) Processor { return NewIgnoreElementsProcessor(conf) },
+	domain.StepTable:       func(conf domain.Setting) Processor { return NewTableProcessor(conf) },
+	domain.StepHTML:        func(conf domain.Setting) Processor { return NewHTMLProcessor(conf) },
+	domain.StepConvert:     func(conf domain.Setting) Processor { return NewConvertProcessor(conf) },
+	domain.StepXPath:       func(conf domain.Setting) Processor { return NewXPathProcessor(conf) },
+	domain.StepXML:         func(conf domain.Setting) Processor { return NewXMLProcessor(conf) },
+	domain.StepJSONPath:    func(conf domain.Setting) Processor { return NewJSONPathProcessor(conf) },
+	domain.StepJQ:          func(conf domain.Setting) Processor { return NewJQProcessor(conf) },
+	domain.StepCSV:         func(conf domain.Setting) Processor { return NewCSVProcessor(conf) },
+	domain.StepRegex:       func(conf domain.Setting) Processor { return NewRegexProcessor(conf) },
+	domain.StepFilter:      func(conf domain.Setting) Processor { return NewFilterProcessor(conf) },
+	domain.StepMask:        func(conf domain.Setting) Processor { return NewMaskProcessor(conf) },
+	domain.StepDeduplicate: func(conf domain.Setting) Processor { return NewDeduplicationProcessor(conf) },
+	domain.StepTrim:        func(conf domain.Setting) Processor { return NewTrimProcessor(conf) },
+	domain.StepSort:        func(conf domain.Setting) Processor { return NewSortProcessor(conf) },
+	domain.StepScript:      func(conf domain.Setting) Processor { return NewScriptProcessor(conf) },
+}
+
+// Stage is the processor of a pipeline step.
+type Stage struct {
+	Type      domain.StepType
+	Processor Processor
+}
+
+// StagesFromSteps builds the processors of the pipeline steps in order, unknown steps are skipped.
+// The plugin steps run the plugins of the registry.
+func StagesFromSteps(steps []domain.ProcessingStep, plugins *PluginRegistry) []Stage {
+	result := make([]Stage, 0, len(steps))
+	for i, step := range steps {
+		if !knownStep(step.Type) {
+			continue
+		}
+
+		conf := step.Setting()
+		if step.Type == domain.StepHTML {
+			// Keep the markup of the selected elements when they are converted later on
+			conf.Convert = nextConvert(steps[i+1:])
+		}
+
+		var processor Processor
+		if step.Type == domain.StepPlugin {
+			processor = NewPluginProcessor(conf, plugins)
+		} else {
+			processor = stepProcessors[step.Type](conf)
+		}
+		result = append(result, Stage{Type: step.Type, Processor: processor})
+	}
+	return result
+}
+
+// FromSteps builds the processors of the pipeline steps in order, unknown steps are skipped.
+func FromSteps(steps []domain.ProcessingStep, plugins *PluginRegistry) []Processor {
+	stages := StagesFromSteps(steps, plugins)
+	result := make([]Processor, 0, len(stages))
+	for _, stage := range stages {
+		result = append(result, stage.Processor)
+	}
+	return result
+}
+
+// ValidateSteps checks the type and the parameters of every step, the plugins must be in the registry.
+func ValidateSteps(steps []domain.ProcessingStep, plugins *PluginRegistry) error {
+	for i, step := range steps {
+		if !knownStep(step.Type) {
+			return fmt.Errorf("step %d: %w: %s", i+1, ErrUnknownStep, step.Type)
+		}
+
+		conf := step.Setting()
+		for _, validate := range []func() error{
+			func() error { return ValidateIgnore(conf.Ignore) },
+			func() error { return ValidateRegex(conf.Regex) },
+			func() error { return ValidateFilter(conf.Filter) },
+			func() error { return ValidateJQ(conf.JQ) },
+			func() error { return ValidateXPath(conf.XPath) },
+			func() error { return ValidateXML(conf.XML) },
+			func() error { return ValidateCSV(conf.CSV) },
+			func() error { return ValidateScript(conf.Script) },
+			func() error { return plugins.Validate(conf.Plugin) },
+			func() error { return ValidateDeduplication(conf.DeduplicationOption) },
+			func() error { return ValidateSort(conf.SortOption) },
+		} {
+			if err := validate(); err != nil {
+				return fmt.Errorf("step %d: %w", i+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+// knownStep reports whether the step type has a processor, the plugin steps are built with the registry.
+func knownStep(stepType domain.StepType) bool {
+	_, ok := stepProcessors[stepType]
+	return ok || stepType == domain.StepPlugin
+}
+
+func nextConvert(steps []domain.ProcessingStep) domain.ConvertOption {
+	for _, step := range steps {
+		if step.Type == domain.StepConvert {
+			return step.Convert
+		}
+	}
+	return domain.ConvertOption{}
+}
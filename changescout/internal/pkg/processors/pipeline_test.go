@@ -0,0 +1,113 @@
+package processors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		steps    []domain.ProcessingStep
+		input    string
+		expected string
+	}{
+		{
+			name: "Select, regex, sort and deduplicate",
+			steps: []domain.ProcessingStep{
+				{Type: domain.StepHTML, Selectors: []string{"li"}},
+				{Type: domain.StepRegex, Regex: domain.RegexOption{Patterns: []string{`v(\d+)`}, Mode: domain.RegexModeAll}},
+				{Type: domain.StepSort},
+				{Type: domain.StepDeduplicate},
+			},
+			input:    `<ul><li>v3</li><li>v1</li><li>v3</li></ul>`,
+			expected: "1\n3\n",
+		},
+		{
+			name: "Chained selectors",
+			steps: []domain.ProcessingStep{
+				{Type: domain.StepHTML, Selectors: []string{"#content"}},
+				{Type: domain.StepConvert, Convert: domain.ConvertOption{Format: domain.ConvertFormatText}},
+			},
+			input:    `<nav>Menu</nav><div id="content"><h1>Title</h1><p>Text</p></div>`,
+			expected: "Title\n\nText",
+		},
+		{
+			name: "Unknown steps are skipped",
+			steps: []domain.ProcessingStep{
+				{Type: "unknown"},
+				{Type: domain.StepTrim},
+			},
+			input:    "  text  ",
+			expected: "text",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := processors.New(processors.FromSteps(test.steps, nil)...)
+			actual, err := runner.Run([]byte(test.input))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestFromSteps_Err(t *testing.T) {
+	runner := processors.New(processors.FromSteps([]domain.ProcessingStep{
+		{Type: domain.StepJQ, JQ: ".items.name"},
+		{Type: domain.StepTrim},
+	}, nil)...)
+
+	_, err := runner.Run([]byte(`{"items":[]}`))
+
+	assert.ErrorIs(t, err, processors.ErrInvalidJQ)
+}
+
+func TestStagesFromSteps(t *testing.T) {
+	stages := processors.StagesFromSteps([]domain.ProcessingStep{
+		{Type: domain.StepHTML, Selectors: []string{"h1"}},
+		{Type: "unknown"},
+		{Type: domain.StepTrim},
+	}, nil)
+
+	assert.Len(t, stages, 2)
+	assert.Equal(t, domain.StepHTML, stages[0].Type)
+	assert.Equal(t, domain.StepTrim, stages[1].Type)
+}
+
+func TestValidateSteps(t *testing.T) {
+	assert.NoError(t, processors.ValidateSteps([]domain.ProcessingStep{
+		{Type: domain.StepHTML, Selectors: []string{"h1"}},
+		{Type: domain.StepSort},
+	}, nil))
+	assert.ErrorIs(t, processors.ValidateSteps([]domain.ProcessingStep{{Type: "unknown"}}, nil), processors.ErrUnknownStep)
+	assert.ErrorIs(t, processors.ValidateSteps([]domain.ProcessingStep{
+		{Type: domain.StepJQ, JQ: ".["},
+	}, nil), processors.ErrInvalidJQ)
+	assert.ErrorIs(t, processors.ValidateSteps([]domain.ProcessingStep{
+		{Type: domain.StepPlugin, Plugin: "upper"},
+	}, nil), processors.ErrUnknownPlugin)
+}
+
+func TestFromSteps_Plugin(t *testing.T) {
+	registry, err := processors.NewPluginRegistry(context.Background(), "testdata/plugins", processors.PluginOptions{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = registry.Close(context.Background()) })
+
+	steps := []domain.ProcessingStep{
+		{Type: domain.StepPlugin, Plugin: "upper"},
+		{Type: domain.StepTrim},
+	}
+	assert.NoError(t, processors.ValidateSteps(steps, registry))
+
+	actual, err := processors.New(processors.FromSteps(steps, registry)...).Run([]byte(" price "))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "PRICE", string(actual))
+}
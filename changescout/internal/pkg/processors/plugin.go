@@ -0,0 +1,196 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	pluginExtension = ".wasm"
+	wasmPageSize    = 64 << 10
+	// The defaults are used when the options are not set.
+	defaultPluginMemoryLimit = 64 << 20
+	defaultPluginTimeout     = 2 * time.Second
+)
+
+var (
+	ErrUnknownPlugin = errors.New("unknown plugin")
+	ErrPluginFailed  = errors.New("plugin failed")
+)
+
+// PluginOptions are the limits of every plugin call.
+type PluginOptions struct {
+	// MemoryLimit is the maximum linear memory of a module in bytes, rounded down to 64KiB pages.
+	MemoryLimit uint32
+	Timeout     time.Duration
+}
+
+// PluginRegistry holds the WebAssembly plugins compiled from a directory.
+// It is built once at startup and passed to the pipelines and the validation of the settings,
+// a nil registry has no plugins.
+//
+// A plugin implements a bytes-in/bytes-out ABI and exports:
+//   - memory: the linear memory
+//   - alloc(size i32) -> i32: returns a pointer to size bytes, where the content is written
+//   - process(ptr i32, len i32) -> i64: processes the content and returns the result as ptr<<32 | len
+//
+// A trap, e.g. `unreachable`, fails the processing. The modules run without the file system, the network
+// and the environment, every call gets a fresh instance.
+type PluginRegistry struct {
+	runtime wazero.Runtime
+	modules map[string]wazero.CompiledModule
+	timeout time.Duration
+}
+
+// NewPluginRegistry compiles the *.wasm modules of the directory, a module is selected by its file name
+// without the extension.
+func NewPluginRegistry(ctx context.Context, dir string, opts PluginOptions) (*PluginRegistry, error) {
+	if opts.MemoryLimit < wasmPageSize {
+		opts.MemoryLimit = defaultPluginMemoryLimit
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultPluginTimeout
+	}
+
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(opts.MemoryLimit/wasmPageSize).
+		WithCloseOnContextDone(true),
+	)
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		return nil, err
+	}
+
+	registry := &PluginRegistry{
+		runtime: runtime,
+		modules: make(map[string]wazero.CompiledModule),
+		timeout: opts.Timeout,
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+pluginExtension))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		binary, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		compiled, err := runtime.CompileModule(ctx, binary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile plugin %s: %w", file, err)
+		}
+		registry.modules[strings.TrimSuffix(filepath.Base(file), pluginExtension)] = compiled
+	}
+
+	return registry, nil
+}
+
+// Validate checks the plugin is registered.
+func (r *PluginRegistry) Validate(name string) error {
+	if name == "" {
+		return nil
+	}
+	if r == nil {
+		return fmt.Errorf("%w: %s, no plugins are loaded", ErrUnknownPlugin, name)
+	}
+	if _, ok := r.modules[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownPlugin, name)
+	}
+	return nil
+}
+
+// Names returns the names of the registered plugins in order.
+func (r *PluginRegistry) Names() []string {
+	names := make([]string, 0, len(r.modules))
+	for name := range r.modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Close releases the compiled modules.
+func (r *PluginRegistry) Close(ctx context.Context) error {
+	return r.runtime.Close(ctx)
+}
+
+func (r *PluginRegistry) run(name string, body []byte) ([]byte, error) {
+	compiled, ok := r.modules[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPlugin, name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	module, err := r.runtime.InstantiateModule(ctx, compiled, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrPluginFailed, name, err)
+	}
+	defer module.Close(context.Background())
+
+	alloc, process := module.ExportedFunction("alloc"), module.ExportedFunction("process")
+	if alloc == nil || process == nil || module.Memory() == nil {
+		return nil, fmt.Errorf("%w: %s must export memory, alloc and process", ErrPluginFailed, name)
+	}
+
+	results, err := alloc.Call(ctx, uint64(len(body)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrPluginFailed, name, err)
+	}
+	ptr := uint32(results[0])
+	if !module.Memory().Write(ptr, body) {
+		return nil, fmt.Errorf("%w: %s: alloc returned memory out of range", ErrPluginFailed, name)
+	}
+
+	results, err = process.Call(ctx, uint64(ptr), uint64(len(body)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrPluginFailed, name, err)
+	}
+	output, ok := module.Memory().Read(uint32(results[0]>>32), uint32(results[0]))
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: result out of memory range", ErrPluginFailed, name)
+	}
+
+	// The memory is released with the module
+	return append([]byte(nil), output...), nil
+}
+
+// PluginProcessor runs a registered WebAssembly plugin over the content.
+type PluginProcessor struct {
+	conf     domain.Setting
+	registry *PluginRegistry
+}
+
+func NewPluginProcessor(conf domain.Setting, registry *PluginRegistry) *PluginProcessor {
+	return &PluginProcessor{
+		conf:     conf,
+		registry: registry,
+	}
+}
+
+func (p *PluginProcessor) Skip() bool {
+	return p.conf.Plugin == ""
+}
+
+func (p *PluginProcessor) Process(body []byte) ([]byte, error) {
+	if p.Skip() {
+		return body, nil
+	}
+	if p.registry == nil {
+		return nil, fmt.Errorf("%w: %s, no plugins are loaded", ErrUnknownPlugin, p.conf.Plugin)
+	}
+	return p.registry.run(p.conf.Plugin, body)
+}
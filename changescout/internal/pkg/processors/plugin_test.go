@@ -0,0 +1,89 @@
+package processors_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginProcessor_Process(t *testing.T) {
+	registry, err := processors.NewPluginRegistry(context.Background(), "testdata/plugins", processors.PluginOptions{
+		MemoryLimit: 1 << 20,
+		Timeout:     200 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = registry.Close(context.Background()) })
+
+	assert.Equal(t, []string{"grow", "loop", "upper"}, registry.Names())
+
+	tests := []struct {
+		name     string
+		plugin   string
+		input    string
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "Transform content",
+			plugin:   "upper",
+			input:    "Price: 10 usd",
+			expected: "PRICE: 10 USD",
+		},
+		{
+			name:    "Time limit",
+			plugin:  "loop",
+			input:   "content",
+			wantErr: processors.ErrPluginFailed,
+		},
+		{
+			name:    "Memory limit",
+			plugin:  "grow",
+			input:   "content",
+			wantErr: processors.ErrPluginFailed,
+		},
+		{
+			name:    "Unknown plugin",
+			plugin:  "missing",
+			input:   "content",
+			wantErr: processors.ErrUnknownPlugin,
+		},
+		{
+			name:     "Without plugin",
+			input:    "content",
+			expected: "content",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewPluginProcessor(domain.Setting{Plugin: test.plugin}, registry)
+			actual, err := p.Process([]byte(test.input))
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+
+}
+
+func TestPluginRegistry_Validate(t *testing.T) {
+	registry, err := processors.NewPluginRegistry(context.Background(), "testdata/plugins", processors.PluginOptions{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = registry.Close(context.Background()) })
+
+	assert.NoError(t, registry.Validate(""))
+	assert.NoError(t, registry.Validate("upper"))
+	assert.ErrorIs(t, registry.Validate("missing"), processors.ErrUnknownPlugin)
+
+	var empty *processors.PluginRegistry
+	assert.NoError(t, empty.Validate(""))
+	assert.ErrorIs(t, empty.Validate("upper"), processors.ErrUnknownPlugin)
+}
@@ -0,0 +1,123 @@
+package processors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidRegexMode = errors.New("invalid regex mode")
+
+type RegexProcessor struct {
+	conf     domain.Setting
+	patterns []*regexp.Regexp
+	err      error
+}
+
+func NewRegexProcessor(conf domain.Setting) *RegexProcessor {
+	p := &RegexProcessor{
+		conf:     conf,
+		patterns: make([]*regexp.Regexp, 0, len(conf.Regex.Patterns)),
+	}
+
+	for _, pattern := range conf.Regex.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			p.err = fmt.Errorf("invalid regex %q: %w", pattern, err)
+			break
+		}
+		p.patterns = append(p.patterns, re)
+	}
+
+	return p
+}
+
+// ValidateRegex checks the patterns and the mode of the regex setting.
+func ValidateRegex(conf domain.RegexOption) error {
+	switch conf.Mode {
+	case "", domain.RegexModeFirst, domain.RegexModeAll, domain.RegexModeGroups:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidRegexMode, conf.Mode)
+	}
+
+	for _, pattern := range conf.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+func (p *RegexProcessor) Skip() bool {
+	return len(p.conf.Regex.Patterns) == 0
+}
+
+func (p *RegexProcessor) Process(body []byte) ([]byte, error) {
+	if p.Skip() {
+		return body, nil
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	if p.conf.Regex.Mode == domain.RegexModeGroups {
+		return p.groups(body)
+	}
+
+	limit := 1
+	if p.conf.Regex.Mode == domain.RegexModeAll {
+		limit = -1
+	}
+
+	var results [][]byte
+	for _, re := range p.patterns {
+		for _, match := range re.FindAllSubmatch(body, limit) {
+			results = append(results, matchValue(match))
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, p.noMatches()
+	}
+
+	return bytes.Join(results, []byte("\n")), nil
+}
+
+// groups renders every match as an object of its named groups.
+func (p *RegexProcessor) groups(body []byte) ([]byte, error) {
+	results := make([]map[string]string, 0)
+	for _, re := range p.patterns {
+		names := re.SubexpNames()
+		for _, match := range re.FindAllSubmatch(body, -1) {
+			result := make(map[string]string)
+			for i, name := range names {
+				if name != "" {
+					result[name] = string(match[i])
+				}
+			}
+			results = append(results, result)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, p.noMatches()
+	}
+
+	return json.Marshal(results)
+}
+
+func (p *RegexProcessor) noMatches() error {
+	return fmt.Errorf("%w: %s", ErrNoMatches, strings.Join(p.conf.Regex.Patterns, ", "))
+}
+
+// matchValue returns the first capture group, or the whole match when the pattern has no groups.
+func matchValue(match [][]byte) []byte {
+	if len(match) > 1 {
+		return match[1]
+	}
+	return match[0]
+}
@@ -0,0 +1,75 @@
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegexProcessor_Process(t *testing.T) {
+	input := []byte("v1.2.3 released for $19.99\nv1.2.4 released for $21.50")
+
+	tests := []struct {
+		name     string
+		conf     domain.RegexOption
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "First match",
+			conf:     domain.RegexOption{Patterns: []string{`v\d+\.\d+\.\d+`}},
+			expected: "v1.2.3",
+		},
+		{
+			name:     "First capture group",
+			conf:     domain.RegexOption{Patterns: []string{`\$(\d+\.\d+)`}, Mode: domain.RegexModeFirst},
+			expected: "19.99",
+		},
+		{
+			name:     "All matches",
+			conf:     domain.RegexOption{Patterns: []string{`v\d+\.\d+\.\d+`, `\$(\d+\.\d+)`}, Mode: domain.RegexModeAll},
+			expected: "v1.2.3\nv1.2.4\n19.99\n21.50",
+		},
+		{
+			name:     "Named groups",
+			conf:     domain.RegexOption{Patterns: []string{`v(?P<version>\S+) released for \$(?P<price>\S+)`}, Mode: domain.RegexModeGroups},
+			expected: `[{"price":"19.99","version":"1.2.3"},{"price":"21.50","version":"1.2.4"}]`,
+		},
+		{
+			name:    "No matches",
+			conf:    domain.RegexOption{Patterns: []string{`beta`}, Mode: domain.RegexModeAll},
+			wantErr: processors.ErrNoMatches,
+		},
+		{
+			name:    "No named group matches",
+			conf:    domain.RegexOption{Patterns: []string{`(?P<beta>beta)`}, Mode: domain.RegexModeGroups},
+			wantErr: processors.ErrNoMatches,
+		},
+		{
+			name:     "Without patterns",
+			conf:     domain.RegexOption{},
+			expected: string(input),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewRegexProcessor(domain.Setting{Regex: test.conf})
+			actual, err := p.Process(input)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestValidateRegex(t *testing.T) {
+	assert.NoError(t, processors.ValidateRegex(domain.RegexOption{Patterns: []string{`\d+`}}))
+	assert.Error(t, processors.ValidateRegex(domain.RegexOption{Patterns: []string{`(`}}))
+	assert.ErrorIs(t, processors.ValidateRegex(domain.RegexOption{Mode: "some"}), processors.ErrInvalidRegexMode)
+}
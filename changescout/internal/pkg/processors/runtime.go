@@ -1,27 +1,67 @@
 package processors
 
+import "errors"
+
+var (
+	// ErrNoMatches is returned when the selectors or expressions of a processor match nothing,
+	// which usually means the page layout changed and the website should be reviewed.
+	ErrNoMatches = errors.New("no matches")
+	// ErrInvalidContent is returned when the content can't be parsed in the format the processor expects.
+	ErrInvalidContent = errors.New("invalid content")
+)
+
 type Processor interface {
 	Skip() bool
-	Process(body []byte) []byte
+	Process(body []byte) ([]byte, error)
+}
+
+// Verdict is implemented by the processors which decide whether the change of the content is significant.
+type Verdict interface {
+	// Significant returns nil when the last processed content has no verdict.
+	Significant() *bool
 }
 
 type ProcessRunner interface {
-	Run(body []byte) []byte
+	Run(body []byte) ([]byte, error)
+	// Significant returns whether any content processed so far was marked as significant,
+	// nil when no processor gave a verdict.
+	Significant() *bool
 }
 
 type runtimeProcessor struct {
-	processors []Processor
+	processors  []Processor
+	significant *bool
 }
 
 func New(processors ...Processor) ProcessRunner {
 	return &runtimeProcessor{processors: processors}
 }
 
-func (p *runtimeProcessor) Run(body []byte) []byte {
+// Run applies the processors in order and stops at the first error.
+func (p *runtimeProcessor) Run(body []byte) ([]byte, error) {
+	var err error
 	for _, processor := range p.processors {
-		if !processor.Skip() {
-			body = processor.Process(body)
+		if processor.Skip() {
+			continue
+		}
+		if body, err = processor.Process(body); err != nil {
+			return nil, err
+		}
+		if verdict, ok := processor.(Verdict); ok {
+			p.record(verdict.Significant())
 		}
 	}
-	return body
+	return body, nil
+}
+
+func (p *runtimeProcessor) Significant() *bool {
+	return p.significant
+}
+
+func (p *runtimeProcessor) record(significant *bool) {
+	if significant == nil {
+		return
+	}
+	verdict := *significant || (p.significant != nil && *p.significant)
+	p.significant = &verdict
 }
@@ -0,0 +1,192 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"go.starlark.net/lib/json"
+	"go.starlark.net/lib/math"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// scriptEntrypoint is the function of the script called with the content.
+	scriptEntrypoint = "process"
+	scriptTimeout    = 2 * time.Second
+	// scriptMaxSteps bounds the CPU time independently of the load of the server.
+	scriptMaxSteps = 10_000_000
+	// scriptMaxOutput caps the content returned by the script.
+	scriptMaxOutput = 10 << 20
+	// scriptMaxMemory bounds the memory of the worker process which runs the script.
+	scriptMaxMemory = 512 << 20
+)
+
+var (
+	ErrInvalidScript = errors.New("invalid script")
+	ErrScriptLimit   = errors.New("script exceeded its limits")
+)
+
+var scriptFileOptions = &syntax.FileOptions{
+	While: true,
+	Set:   true,
+}
+
+var scriptModules = starlark.StringDict{
+	"json": json.Module,
+	"math": math.Module,
+}
+
+// ScriptProcessor runs a sandboxed Starlark function over the content. The script runs in a worker
+// process, has no access to the file system or the network and is stopped when it exceeds its time,
+// step or memory limits.
+type ScriptProcessor struct {
+	conf        domain.Setting
+	significant *bool
+}
+
+func NewScriptProcessor(conf domain.Setting) *ScriptProcessor {
+	return &ScriptProcessor{conf: conf}
+}
+
+// ValidateScript checks the script compiles and defines the process function.
+func ValidateScript(src string) error {
+	if src == "" {
+		return nil
+	}
+	// Loading runs the top level statements of the script, so it is limited as well
+	_, err := runWorker(scriptJob{Script: src, Validate: true})
+	return err
+}
+
+func (p *ScriptProcessor) Skip() bool {
+	return p.conf.Script == ""
+}
+
+// Significant returns the verdict of the last processed content, nil when the script returned no verdict.
+func (p *ScriptProcessor) Significant() *bool {
+	return p.significant
+}
+
+// Process calls process(body) of the script, which returns either the content or a (content, significant) tuple.
+func (p *ScriptProcessor) Process(body []byte) ([]byte, error) {
+	p.significant = nil
+	if p.Skip() {
+		return body, nil
+	}
+
+	result, err := runWorker(scriptJob{Script: p.conf.Script, Body: body})
+	if err != nil {
+		return nil, err
+	}
+	p.significant = result.Significant
+
+	return result.Output, nil
+}
+
+// evalScript runs the job in the current process, it is called by the worker process.
+func evalScript(job scriptJob) ([]byte, *bool, error) {
+	var result starlark.Value
+	err := sandbox(func(thread *starlark.Thread) error {
+		process, err := loadScript(thread, job.Script)
+		if err != nil || job.Validate {
+			return err
+		}
+		result, err = starlark.Call(thread, process, starlark.Tuple{starlark.String(job.Body)}, nil)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidScript, err)
+		}
+		return nil
+	})
+	if err != nil || job.Validate {
+		return nil, nil, err
+	}
+
+	return scriptResult(result)
+}
+
+func newScriptThread() *starlark.Thread {
+	thread := &starlark.Thread{
+		Name:  "script",
+		Print: func(*starlark.Thread, string) {},
+	}
+	thread.SetMaxExecutionSteps(scriptMaxSteps)
+	return thread
+}
+
+func loadScript(thread *starlark.Thread, src string) (starlark.Value, error) {
+	globals, err := starlark.ExecFileOptions(scriptFileOptions, thread, "script.star", src, scriptModules)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScript, err)
+	}
+
+	process, ok := globals[scriptEntrypoint].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%w: function %s(body) is not defined", ErrInvalidScript, scriptEntrypoint)
+	}
+	return process, nil
+}
+
+// sandbox runs fn on a new thread which is cancelled on the timeout.
+func sandbox(fn func(thread *starlark.Thread) error) error {
+	thread := newScriptThread()
+
+	ctx, cancel := context.WithTimeout(context.Background(), scriptTimeout)
+	defer cancel()
+
+	var timedOut atomic.Bool
+	go func() {
+		<-ctx.Done()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			timedOut.Store(true)
+			thread.Cancel("timeout")
+		}
+	}()
+
+	err := fn(thread)
+	if timedOut.Load() {
+		return fmt.Errorf("%w: timeout of %s", ErrScriptLimit, scriptTimeout)
+	}
+	if thread.ExecutionSteps() >= scriptMaxSteps {
+		return fmt.Errorf("%w: %d steps", ErrScriptLimit, scriptMaxSteps)
+	}
+	return err
+}
+
+func scriptResult(value starlark.Value) ([]byte, *bool, error) {
+	if tuple, ok := value.(starlark.Tuple); ok && len(tuple) == 2 {
+		output, err := scriptOutput(tuple[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		significant, ok := tuple[1].(starlark.Bool)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: significant must be a bool, got %s", ErrInvalidScript, tuple[1].Type())
+		}
+		verdict := bool(significant)
+		return output, &verdict, nil
+	}
+
+	output, err := scriptOutput(value)
+	return output, nil, err
+}
+
+func scriptOutput(value starlark.Value) ([]byte, error) {
+	var output []byte
+	switch v := value.(type) {
+	case starlark.String:
+		output = []byte(v)
+	case starlark.Bytes:
+		output = []byte(v)
+	default:
+		return nil, fmt.Errorf("%w: %s must return a string or a (string, bool) tuple, got %s", ErrInvalidScript, scriptEntrypoint, value.Type())
+	}
+
+	if len(output) > scriptMaxOutput {
+		return nil, fmt.Errorf("%w: output of %d MB", ErrScriptLimit, scriptMaxOutput>>20)
+	}
+	return output, nil
+}
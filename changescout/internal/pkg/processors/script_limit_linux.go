@@ -0,0 +1,9 @@
+package processors
+
+import "syscall"
+
+// limitMemory caps the data memory of the current process, an allocation over it fails.
+// Unlike the address space, it doesn't count the memory the Go runtime only reserves.
+func limitMemory(max uint64) error {
+	return syscall.Setrlimit(syscall.RLIMIT_DATA, &syscall.Rlimit{Cur: max, Max: max})
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package processors
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// limitMemory fails where the address space can't be capped, scripts don't run without a memory limit.
+func limitMemory(uint64) error {
+	return fmt.Errorf("%w: memory limit is not supported on %s", ErrScriptLimit, runtime.GOOS)
+}
@@ -0,0 +1,148 @@
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScriptProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name        string
+		script      string
+		input       string
+		expected    string
+		significant *bool
+		wantErr     error
+	}{
+		{
+			name: "Drop lines",
+			script: `
+def process(body):
+    return "\n".join([line for line in body.splitlines() if "ad" not in line])
+`,
+			input:    "news\nad banner\nweather",
+			expected: "news\nweather",
+		},
+		{
+			name: "Total from JSON fields",
+			script: `
+def process(body):
+    items = json.decode(body)["items"]
+    return str(sum([item["price"] * item["count"] for item in items]))
+
+def sum(values):
+    total = 0
+    for value in values:
+        total += value
+    return total
+`,
+			input:    `{"items":[{"price":2,"count":3},{"price":5,"count":1}]}`,
+			expected: "11",
+		},
+		{
+			name: "Significant verdict",
+			script: `
+def process(body):
+    return body, int(body) > 100
+`,
+			input:       "42",
+			expected:    "42",
+			significant: boolPtr(false),
+		},
+		{
+			name:    "Invalid result",
+			script:  "def process(body):\n    return 1\n",
+			input:   "content",
+			wantErr: processors.ErrInvalidScript,
+		},
+		{
+			name:    "Runtime error",
+			script:  "def process(body):\n    return body + 1\n",
+			input:   "content",
+			wantErr: processors.ErrInvalidScript,
+		},
+		{
+			name:    "Step limit",
+			script:  "def process(body):\n    while True:\n        pass\n",
+			input:   "content",
+			wantErr: processors.ErrScriptLimit,
+		},
+		{
+			name: "Memory limit",
+			script: `
+def process(body):
+    parts = []
+    for i in range(16):
+        parts.append("x" * (1 << 26) + str(i))
+    return "done"
+`,
+			input:   "content",
+			wantErr: processors.ErrScriptLimit,
+		},
+		{
+			name:     "Without script",
+			input:    "content",
+			expected: "content",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewScriptProcessor(domain.Setting{Script: test.script})
+			actual, err := p.Process([]byte(test.input))
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+			assert.Equal(t, test.significant, p.Significant())
+		})
+	}
+}
+
+func TestScriptProcessor_OutputLimit(t *testing.T) {
+	p := processors.NewScriptProcessor(domain.Setting{Script: `
+def process(body):
+    return body * (11 * 1024 * 1024)
+`})
+
+	_, err := p.Process([]byte("x"))
+
+	assert.ErrorIs(t, err, processors.ErrScriptLimit)
+}
+
+func TestValidateScript(t *testing.T) {
+	assert.NoError(t, processors.ValidateScript(""))
+	assert.NoError(t, processors.ValidateScript("def process(body):\n    return body\n"))
+	assert.ErrorIs(t, processors.ValidateScript("def process(body)"), processors.ErrInvalidScript)
+	assert.ErrorIs(t, processors.ValidateScript("def transform(body):\n    return body\n"), processors.ErrInvalidScript)
+	assert.ErrorIs(t, processors.ValidateScript("load('os', 'system')\ndef process(body):\n    return body\n"), processors.ErrInvalidScript)
+}
+
+func TestRunner_Significant(t *testing.T) {
+	runner := processors.New(processors.NewScriptProcessor(domain.Setting{
+		Script: "def process(body):\n    return body, body == \"important\"\n",
+	}))
+
+	assert.Nil(t, runner.Significant())
+
+	_, err := runner.Run([]byte("noise"))
+	assert.NoError(t, err)
+	assert.Equal(t, boolPtr(false), runner.Significant())
+
+	_, err = runner.Run([]byte("important"))
+	assert.NoError(t, err)
+	assert.Equal(t, boolPtr(true), runner.Significant())
+
+	_, err = runner.Run([]byte("noise"))
+	assert.NoError(t, err)
+	assert.Equal(t, boolPtr(true), runner.Significant(), "any significant page marks the content significant")
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
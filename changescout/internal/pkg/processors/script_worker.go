@@ -0,0 +1,159 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+const (
+	// scriptWorkerEnv marks the process started by runWorker, see init.
+	scriptWorkerEnv = "CHANGESCOUT_SCRIPT_WORKER"
+	// scriptWorkerGrace is the time given to the worker to start and report the timeout of the script itself.
+	scriptWorkerGrace = 3 * time.Second
+	// scriptWorkerMaxStderr caps the error output kept from a crashed worker.
+	scriptWorkerMaxStderr = 4 << 10
+)
+
+// scriptJob is sent to the worker process, Validate only loads the script.
+type scriptJob struct {
+	Script   string `json:"script"`
+	Body     []byte `json:"body"`
+	Validate bool   `json:"validate"`
+}
+
+// scriptJobResult is returned by the worker process, the sentinel of the error is kept in ErrorKind.
+type scriptJobResult struct {
+	Output      []byte `json:"output"`
+	Significant *bool  `json:"significant"`
+	Error       string `json:"error"`
+	ErrorKind   string `json:"error_kind"`
+}
+
+const (
+	errorKindInvalid = "invalid"
+	errorKindLimit   = "limit"
+)
+
+// init turns the process started by runWorker into the worker, before anything else of the binary runs.
+// It works for every binary linking this package, the server as well as the test binaries.
+func init() {
+	if os.Getenv(scriptWorkerEnv) == "" {
+		return
+	}
+	os.Exit(serveWorker(os.Stdin, os.Stdout))
+}
+
+// serveWorker limits the memory of the process, runs the job read from in and writes the result to out.
+// An allocation over the limit crashes the process, which is reported by runWorker.
+func serveWorker(in io.Reader, out io.Writer) int {
+	var job scriptJob
+	if err := json.NewDecoder(in).Decode(&job); err != nil {
+		return 1
+	}
+
+	var result scriptJobResult
+	err := limitMemory(scriptMaxMemory)
+	if err == nil {
+		// The garbage is collected before the limit is reached, only the live memory makes the script fail
+		debug.SetMemoryLimit(scriptMaxMemory / 2)
+		result.Output, result.Significant, err = evalScript(job)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		switch {
+		case errors.Is(err, ErrScriptLimit):
+			result.ErrorKind = errorKindLimit
+			result.Error = strings.TrimPrefix(result.Error, ErrScriptLimit.Error()+": ")
+		case errors.Is(err, ErrInvalidScript):
+			result.ErrorKind = errorKindInvalid
+			result.Error = strings.TrimPrefix(result.Error, ErrInvalidScript.Error()+": ")
+		}
+	}
+
+	if err := json.NewEncoder(out).Encode(result); err != nil {
+		return 1
+	}
+	return 0
+}
+
+// runWorker runs the job in a new process of the current binary, which is killed when it outlives the script timeout.
+func runWorker(job scriptJob) (scriptJobResult, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return scriptJobResult{}, fmt.Errorf("failed to start the script worker: %w", err)
+	}
+	input, err := json.Marshal(job)
+	if err != nil {
+		return scriptJobResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scriptTimeout+scriptWorkerGrace)
+	defer cancel()
+
+	// The output is JSON encoded, the content takes a third more as base64
+	stdout := &limitedBuffer{max: 2 * scriptMaxOutput}
+	stderr := &limitedBuffer{max: scriptWorkerMaxStderr}
+	cmd := exec.CommandContext(ctx, executable)
+	cmd.Env = []string{scriptWorkerEnv + "=1"}
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = time.Second
+
+	runErr := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return scriptJobResult{}, fmt.Errorf("%w: timeout of %s", ErrScriptLimit, scriptTimeout)
+	}
+	if stdout.exceeded {
+		return scriptJobResult{}, fmt.Errorf("%w: output of %d MB", ErrScriptLimit, scriptMaxOutput>>20)
+	}
+
+	var result scriptJobResult
+	if runErr != nil || json.Unmarshal(stdout.Bytes(), &result) != nil {
+		if bytes.Contains(stderr.Bytes(), []byte("out of memory")) {
+			return scriptJobResult{}, fmt.Errorf("%w: memory of %d MB", ErrScriptLimit, scriptMaxMemory>>20)
+		}
+		return scriptJobResult{}, fmt.Errorf("script worker failed: %v: %s", runErr, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	switch result.ErrorKind {
+	case errorKindLimit:
+		return scriptJobResult{}, fmt.Errorf("%w: %s", ErrScriptLimit, result.Error)
+	case errorKindInvalid:
+		return scriptJobResult{}, fmt.Errorf("%w: %s", ErrInvalidScript, result.Error)
+	}
+	if result.Error != "" {
+		return scriptJobResult{}, errors.New(result.Error)
+	}
+	return result, nil
+}
+
+// limitedBuffer keeps up to max bytes and drops the rest, so the worker can't exhaust the memory of the server.
+// The buffer is not embedded, its ReadFrom would be used by io.Copy instead of Write.
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	max      int
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.buf.Len(); len(p) > room {
+		b.exceeded = true
+		b.buf.Write(p[:max(room, 0)])
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
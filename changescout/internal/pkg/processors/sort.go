@@ -2,48 +2,101 @@ package processors
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"github.com/gelleson/changescout/changescout/internal/domain"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 )
 
+var ErrInvalidSortMode = errors.New("invalid sort mode")
+
+var firstNumber = regexp.MustCompile(`[-+]?\d*\.?\d+`)
+
 type SortProcessor struct {
 	conf domain.Setting
+	key  *regexp.Regexp
+	err  error
 }
 
 func NewSortProcessor(conf domain.Setting) *SortProcessor {
-	return &SortProcessor{
+	p := &SortProcessor{
 		conf: conf,
 	}
+	p.err = ValidateSort(conf.SortOption)
+	if p.err == nil && conf.SortOption.KeyPattern != "" {
+		p.key = regexp.MustCompile(conf.SortOption.KeyPattern)
+	}
+	return p
+}
+
+// ValidateSort checks the mode and the key pattern.
+func ValidateSort(conf domain.SortOption) error {
+	switch conf.Mode {
+	case "", domain.SortModeLexical, domain.SortModeNumeric, domain.SortModeNatural:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidSortMode, conf.Mode)
+	}
+	if conf.KeyPattern != "" {
+		if _, err := regexp.Compile(conf.KeyPattern); err != nil {
+			return fmt.Errorf("invalid sort key pattern %q: %w", conf.KeyPattern, err)
+		}
+	}
+	return nil
 }
 
 func (p *SortProcessor) Skip() bool {
 	return !p.conf.Sort
 }
 
-func (p *SortProcessor) Process(body []byte) []byte {
+func (p *SortProcessor) Process(body []byte) ([]byte, error) {
 	// If sorting is disabled, return the original body
 	if p.Skip() {
-		return body
+		return body, nil
+	}
+	if p.err != nil {
+		return nil, p.err
 	}
 
 	// If body is empty, return empty slice (not nil)
 	if len(body) == 0 {
-		return []byte{}
+		return []byte{}, nil
 	}
 
 	// Split the input string by newlines
 	lines := bytes.Split(body, []byte{'\n'})
 
 	// Create a slice for sorting
-	var sortedLines [][]byte
+	var sortedLines []string
 	for _, line := range lines {
 		// We only want non-empty lines
 		if len(line) > 0 {
-			sortedLines = append(sortedLines, line)
+			sortedLines = append(sortedLines, string(line))
 		}
 	}
 
-	// Sort the lines
-	sortBytes(sortedLines)
+	keys := make(map[string]string, len(sortedLines))
+	for _, line := range sortedLines {
+		keys[line] = p.sortKey(line)
+	}
+
+	compare := compareLexical
+	switch p.conf.SortOption.Mode {
+	case domain.SortModeNumeric:
+		compare = compareNumeric
+	case domain.SortModeNatural:
+		compare = compareNatural
+	}
+
+	sort.SliceStable(sortedLines, func(i, j int) bool {
+		result := compare(keys[sortedLines[i]], keys[sortedLines[j]])
+		if p.conf.SortOption.Reverse {
+			return result > 0
+		}
+		return result < 0
+	})
 
 	// Join the sorted lines back into a single byte slice with newline
 	var output []byte
@@ -52,15 +105,98 @@ func (p *SortProcessor) Process(body []byte) []byte {
 		output = append(output, '\n') // Append newline after each line
 	}
 
-	return output
+	return output, nil
+}
+
+func (p *SortProcessor) sortKey(line string) string {
+	if p.key == nil {
+		return line
+	}
+	match := p.key.FindStringSubmatch(line)
+	switch {
+	case match == nil:
+		return line
+	case len(match) > 1:
+		return match[1]
+	default:
+		return match[0]
+	}
+}
+
+func compareLexical(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+// compareNumeric compares the first numbers, the keys without numbers go after the numbers.
+func compareNumeric(a, b string) int {
+	x, xOK := parseFirstNumber(a)
+	y, yOK := parseFirstNumber(b)
+	switch {
+	case xOK && yOK:
+		if x < y {
+			return -1
+		}
+		if x > y {
+			return 1
+		}
+		return 0
+	case xOK:
+		return -1
+	case yOK:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func parseFirstNumber(value string) (float64, bool) {
+	match := firstNumber.FindString(value)
+	if match == "" {
+		return 0, false
+	}
+	number, err := strconv.ParseFloat(match, 64)
+	return number, err == nil
 }
 
-func sortBytes(lines [][]byte) {
-	for i := 0; i < len(lines); i++ {
-		for j := i + 1; j < len(lines); j++ {
-			if bytes.Compare(lines[i], lines[j]) > 0 {
-				lines[i], lines[j] = lines[j], lines[i]
+// compareNatural compares the digit runs by their value and the other runs byte by byte.
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		x, restA := nextChunk(a)
+		y, restB := nextChunk(b)
+		if isDigit(x[0]) && isDigit(y[0]) {
+			x, y = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+			if len(x) != len(y) {
+				return compareInt(len(x), len(y))
 			}
 		}
+		if result := strings.Compare(x, y); result != 0 {
+			return result
+		}
+		a, b = restA, restB
+	}
+	return compareInt(len(a), len(b))
+}
+
+func nextChunk(value string) (chunk, rest string) {
+	digit := isDigit(value[0])
+	i := 1
+	for i < len(value) && isDigit(value[i]) == digit {
+		i++
+	}
+	return value[:i], value[i:]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
 	}
 }
@@ -63,17 +63,100 @@ func TestSortProcessor_Process(t *testing.T) {
 			input:    []byte("banana\napple\nbanana\ncherry\n"),
 			expected: []byte("apple\nbanana\nbanana\ncherry\n"),
 		},
+		{
+			name: "Reverse",
+			conf: domain.Setting{
+				Sort:       true,
+				SortOption: domain.SortOption{Reverse: true},
+			},
+			input:    []byte("banana\napple\ncherry\n"),
+			expected: []byte("cherry\nbanana\napple\n"),
+		},
+		{
+			name: "Numeric",
+			conf: domain.Setting{
+				Sort:       true,
+				SortOption: domain.SortOption{Mode: domain.SortModeNumeric},
+			},
+			input:    []byte("10 items\nnone\n9.5 items\n-1 items\n100 items\n"),
+			expected: []byte("-1 items\n9.5 items\n10 items\n100 items\nnone\n"),
+		},
+		{
+			name: "Natural",
+			conf: domain.Setting{
+				Sort:       true,
+				SortOption: domain.SortOption{Mode: domain.SortModeNatural},
+			},
+			input:    []byte("file10\nfile2\nfile1\nfile02b\n"),
+			expected: []byte("file1\nfile2\nfile02b\nfile10\n"),
+		},
+		{
+			name: "Key pattern",
+			conf: domain.Setting{
+				Sort: true,
+				SortOption: domain.SortOption{
+					Mode:       domain.SortModeNumeric,
+					KeyPattern: `price: (\d+)`,
+				},
+			},
+			input:    []byte("b1 price: 30\na2 price: 5\nc3 price: 12\n"),
+			expected: []byte("a2 price: 5\nc3 price: 12\nb1 price: 30\n"),
+		},
+		{
+			name: "Stable order of equal keys",
+			conf: domain.Setting{
+				Sort:       true,
+				SortOption: domain.SortOption{KeyPattern: `^\w`},
+			},
+			input:    []byte("b2\na2\nb1\na1\n"),
+			expected: []byte("a2\na1\nb2\nb1\n"),
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			p := processors.NewSortProcessor(test.conf)
-			actual := p.Process(test.input)
+			actual, err := p.Process(test.input)
+			assert.NoError(t, err)
 			assert.Equal(t, test.expected, actual)
 		})
 	}
 }
 
+func TestValidateSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    domain.SortOption
+		wantErr bool
+	}{
+		{name: "Default", conf: domain.SortOption{}},
+		{name: "Natural", conf: domain.SortOption{Mode: domain.SortModeNatural, Reverse: true}},
+		{name: "Key pattern", conf: domain.SortOption{KeyPattern: `id=(\d+)`}},
+		{name: "Unknown mode", conf: domain.SortOption{Mode: "random"}, wantErr: true},
+		{name: "Invalid key pattern", conf: domain.SortOption{KeyPattern: `(`}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := processors.ValidateSort(test.conf)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSortProcessor_InvalidOption(t *testing.T) {
+	p := processors.NewSortProcessor(domain.Setting{
+		Sort:       true,
+		SortOption: domain.SortOption{Mode: "random"},
+	})
+	_, err := p.Process([]byte("b\na\n"))
+	assert.ErrorIs(t, err, processors.ErrInvalidSortMode)
+}
+
 func TestSortProcessor_Skip(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,156 @@
+package processors
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"sort"
+	"strings"
+)
+
+type TableProcessor struct {
+	conf domain.Setting
+}
+
+func NewTableProcessor(conf domain.Setting) *TableProcessor {
+	return &TableProcessor{
+		conf: conf,
+	}
+}
+
+func (p *TableProcessor) Skip() bool {
+	return p.conf.Table.Selector == ""
+}
+
+// Process renders the table with one row per line, so the diff reports changes row by row.
+func (p *TableProcessor) Process(body []byte) ([]byte, error) {
+	if p.Skip() {
+		return body, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidContent, err)
+	}
+
+	table := doc.Find(p.conf.Table.Selector).First()
+	if table.Length() == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatches, p.conf.Table.Selector)
+	}
+
+	header, rows := readTable(table)
+	sortRows(header, rows, p.conf.Table.KeyColumns)
+
+	if p.conf.Table.Format == domain.TableFormatCSV {
+		return tableCSV(header, rows), nil
+	}
+	return tableJSON(header, rows), nil
+}
+
+// readTable returns the header and the data rows of the table.
+// The header is the row of th cells, or the first row when the table has none.
+func readTable(table *goquery.Selection) ([]string, [][]string) {
+	var header []string
+	var rows [][]string
+
+	table.Find("tr").Each(func(i int, tr *goquery.Selection) {
+		// Skip the rows of nested tables
+		if tr.Closest("table").Get(0) != table.Get(0) {
+			return
+		}
+
+		var cells []string
+		headerRow := tr.Find("td").Length() == 0
+		tr.Find("th, td").Each(func(i int, cell *goquery.Selection) {
+			cells = append(cells, strings.Join(strings.Fields(cell.Text()), " "))
+		})
+		if len(cells) == 0 {
+			return
+		}
+
+		if header == nil && (headerRow || len(rows) == 0) {
+			header = cells
+			return
+		}
+		rows = append(rows, cells)
+	})
+
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		if name == "" || seen[name] {
+			header[i] = fmt.Sprintf("column_%d", i+1)
+		}
+		seen[header[i]] = true
+	}
+
+	for i, row := range rows {
+		for len(header) < len(row) {
+			header = append(header, fmt.Sprintf("column_%d", len(header)+1))
+		}
+		for len(row) < len(header) {
+			row = append(row, "")
+		}
+		rows[i] = row
+	}
+
+	return header, rows
+}
+
+func sortRows(header []string, rows [][]string, keyColumns []string) {
+	var keys []int
+	for _, column := range keyColumns {
+		for i, name := range header {
+			if name == column {
+				keys = append(keys, i)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, key := range keys {
+			if rows[i][key] != rows[j][key] {
+				return rows[i][key] < rows[j][key]
+			}
+		}
+		return false
+	})
+}
+
+func tableJSON(header []string, rows [][]string) []byte {
+	lines := make([][]byte, 0, len(rows))
+	for _, row := range rows {
+		record := make(map[string]string, len(header))
+		for i, name := range header {
+			record[name] = row[i]
+		}
+		line, err := json.Marshal(record)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return []byte(`[]`)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("[\n")
+	buf.Write(bytes.Join(lines, []byte(",\n")))
+	buf.WriteString("\n]")
+	return buf.Bytes()
+}
+
+func tableCSV(header []string, rows [][]string) []byte {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write(header)
+	_ = writer.WriteAll(rows)
+	return bytes.TrimRight(buf.Bytes(), "\n")
+}
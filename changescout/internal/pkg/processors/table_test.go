@@ -0,0 +1,83 @@
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableProcessor_Process(t *testing.T) {
+	input := []byte(`
+		<html>
+			<body>
+				<table id="pricing">
+					<thead><tr><th>Plan</th><th>Price</th><th></th></tr></thead>
+					<tbody>
+						<tr><td>Pro</td><td>$20</td><td>Popular</td></tr>
+						<tr><td>Basic</td><td>$10</td><td></td></tr>
+						<tr><td>Team, Plus</td><td> $50 </td></tr>
+					</tbody>
+				</table>
+				<table id="plain">
+					<tr><td>Name</td><td>Status</td></tr>
+					<tr><td>API</td><td>Up</td></tr>
+				</table>
+			</body>
+		</html>
+	`)
+
+	tests := []struct {
+		name     string
+		conf     domain.TableOption
+		expected string
+		wantErr  error
+	}{
+		{
+			name: "JSON rows",
+			conf: domain.TableOption{Selector: "#pricing"},
+			expected: "[\n" +
+				`{"Plan":"Pro","Price":"$20","column_3":"Popular"},` + "\n" +
+				`{"Plan":"Basic","Price":"$10","column_3":""},` + "\n" +
+				`{"Plan":"Team, Plus","Price":"$50","column_3":""}` + "\n" +
+				"]",
+		},
+		{
+			name: "CSV rows sorted by key columns",
+			conf: domain.TableOption{Selector: "#pricing", Format: domain.TableFormatCSV, KeyColumns: []string{"Plan"}},
+			expected: "Plan,Price,column_3\n" +
+				"Basic,$10,\n" +
+				"Pro,$20,Popular\n" +
+				"\"Team, Plus\",$50,",
+		},
+		{
+			name:     "First row as header",
+			conf:     domain.TableOption{Selector: "#plain"},
+			expected: "[\n" + `{"Name":"API","Status":"Up"}` + "\n]",
+		},
+		{
+			name:    "Table not found",
+			conf:    domain.TableOption{Selector: "#missing"},
+			wantErr: processors.ErrNoMatches,
+		},
+		{
+			name:     "Without selector",
+			conf:     domain.TableOption{},
+			expected: string(input),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewTableProcessor(domain.Setting{Table: test.conf})
+			actual, err := p.Process(input)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
@@ -0,0 +1,9 @@
+;; grow.wasm: traps when the memory can't grow by 1000 pages, used to test the memory limit.
+(module
+  (memory (export "memory") 1)
+  (func (export "alloc") (param $size i32) (result i32)
+    i32.const 1024)
+  (func (export "process") (param $ptr i32) (param $len i32) (result i64)
+    (if (i32.eq (memory.grow (i32.const 1000)) (i32.const -1))
+      (then unreachable))
+    i64.const 0))
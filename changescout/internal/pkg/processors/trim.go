@@ -19,14 +19,14 @@ func (p *TrimProcessor) Skip() bool {
 	return !p.conf.Trim
 }
 
-func (p *TrimProcessor) Process(body []byte) []byte {
+func (p *TrimProcessor) Process(body []byte) ([]byte, error) {
 	if p.Skip() {
-		return body
+		return body, nil
 	}
 
 	trimmed := bytes.TrimSpace(body)
 	if trimmed == nil {
-		return []byte{}
+		return []byte{}, nil
 	}
-	return trimmed
+	return trimmed, nil
 }
@@ -60,7 +60,8 @@ func TestTrimProcessor_Process(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			p := processors.NewTrimProcessor(test.conf)
-			actual := p.Process(test.input)
+			actual, err := p.Process(test.input)
+			assert.NoError(t, err)
 			assert.Equal(t, test.expected, actual)
 		})
 	}
@@ -0,0 +1,176 @@
+package processors
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type XMLProcessor struct {
+	conf domain.Setting
+}
+
+func NewXMLProcessor(conf domain.Setting) *XMLProcessor {
+	return &XMLProcessor{
+		conf: conf,
+	}
+}
+
+// ValidateXML checks the XPath expressions compile with the configured namespaces.
+func ValidateXML(conf domain.XMLOption) error {
+	namespaces := xmlNamespaces(conf.Namespaces)
+	for _, expr := range conf.XPath {
+		if _, err := xpath.CompileWithNS(expr, namespaces); err != nil {
+			return fmt.Errorf("invalid xpath %q: %w", expr, err)
+		}
+	}
+	return nil
+}
+
+func (p *XMLProcessor) Skip() bool {
+	return p.conf.XML.IsZero()
+}
+
+// Process writes the matched nodes, or the whole document, in a canonical form:
+// sorted attributes, trimmed text and two-space indentation, so formatting changes are not reported.
+func (p *XMLProcessor) Process(body []byte) ([]byte, error) {
+	if p.Skip() {
+		return body, nil
+	}
+
+	doc, err := xmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidContent, err)
+	}
+
+	if len(p.conf.XML.XPath) == 0 {
+		var buf bytes.Buffer
+		writeCanonicalChildren(&buf, doc, 0)
+		return bytes.TrimSpace(buf.Bytes()), nil
+	}
+
+	namespaces := xmlNamespaces(p.conf.XML.Namespaces)
+	var results []string
+	for _, expr := range p.conf.XML.XPath {
+		compiled, err := xpath.CompileWithNS(expr, namespaces)
+		if err != nil {
+			return nil, fmt.Errorf("invalid xpath %q: %w", expr, err)
+		}
+
+		switch value := compiled.Evaluate(xmlquery.CreateXPathNavigator(doc)).(type) {
+		case *xpath.NodeIterator:
+			for value.MoveNext() {
+				current := value.Current()
+				if current.NodeType() == xpath.ElementNode {
+					var buf bytes.Buffer
+					writeCanonical(&buf, current.(*xmlquery.NodeNavigator).Current(), 0)
+					results = append(results, strings.TrimSpace(buf.String()))
+				} else {
+					results = append(results, current.Value())
+				}
+			}
+		case float64:
+			results = append(results, strconv.FormatFloat(value, 'f', -1, 64))
+		default:
+			results = append(results, fmt.Sprint(value))
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatches, strings.Join(p.conf.XML.XPath, ", "))
+	}
+
+	return []byte(strings.Join(results, "\n")), nil
+}
+
+func xmlNamespaces(namespaces []domain.XMLNamespace) map[string]string {
+	result := make(map[string]string, len(namespaces))
+	for _, ns := range namespaces {
+		result[ns.Prefix] = ns.URI
+	}
+	return result
+}
+
+func writeCanonicalChildren(buf *bytes.Buffer, node *xmlquery.Node, depth int) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeCanonical(buf, child, depth)
+	}
+}
+
+func writeCanonical(buf *bytes.Buffer, node *xmlquery.Node, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	switch node.Type {
+	case xmlquery.TextNode, xmlquery.CharDataNode:
+		if text := strings.TrimSpace(node.Data); text != "" {
+			buf.WriteString(indent)
+			_ = xml.EscapeText(buf, []byte(text))
+			buf.WriteString("\n")
+		}
+	case xmlquery.CommentNode:
+		buf.WriteString(indent + "<!--" + node.Data + "-->\n")
+	case xmlquery.ElementNode:
+		name := qualifiedName(node.Prefix, node.Data)
+		buf.WriteString(indent + "<" + name)
+		writeAttributes(buf, node.Attr)
+
+		if node.FirstChild == nil {
+			buf.WriteString("/>\n")
+			return
+		}
+
+		// Elements with only text are kept on one line
+		if text, ok := onlyText(node); ok {
+			buf.WriteString(">")
+			_ = xml.EscapeText(buf, []byte(text))
+			buf.WriteString("</" + name + ">\n")
+			return
+		}
+
+		buf.WriteString(">\n")
+		writeCanonicalChildren(buf, node, depth+1)
+		buf.WriteString(indent + "</" + name + ">\n")
+	}
+}
+
+func writeAttributes(buf *bytes.Buffer, attrs []xmlquery.Attr) {
+	names := make([]string, 0, len(attrs))
+	values := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		name := qualifiedName(attr.Name.Space, attr.Name.Local)
+		names = append(names, name)
+		values[name] = attr.Value
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		buf.WriteString(" " + name + `="`)
+		_ = xml.EscapeText(buf, []byte(values[name]))
+		buf.WriteString(`"`)
+	}
+}
+
+// onlyText returns the trimmed text of an element without child elements.
+func onlyText(node *xmlquery.Node) (string, bool) {
+	var text strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != xmlquery.TextNode && child.Type != xmlquery.CharDataNode {
+			return "", false
+		}
+		text.WriteString(child.Data)
+	}
+	return strings.TrimSpace(text.String()), true
+}
+
+func qualifiedName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + ":" + name
+}
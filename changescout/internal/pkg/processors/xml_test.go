@@ -0,0 +1,95 @@
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXMLProcessor_Process(t *testing.T) {
+	input := []byte(`<?xml version="1.0"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+  <entry><title>  Release 1.0 </title><media:thumbnail width="10" url="a.png"/></entry>
+  <entry>
+    <title>Release 1.1</title>
+  </entry>
+</feed>`)
+	namespaces := []domain.XMLNamespace{
+		{Prefix: "atom", URI: "http://www.w3.org/2005/Atom"},
+		{Prefix: "m", URI: "http://search.yahoo.com/mrss/"},
+	}
+
+	tests := []struct {
+		name     string
+		conf     domain.XMLOption
+		expected string
+	}{
+		{
+			name: "Pretty-print the document",
+			conf: domain.XMLOption{Enabled: true},
+			expected: `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+  <entry>
+    <title>Release 1.0</title>
+    <media:thumbnail url="a.png" width="10"/>
+  </entry>
+  <entry>
+    <title>Release 1.1</title>
+  </entry>
+</feed>`,
+		},
+		{
+			name:     "Namespaced text",
+			conf:     domain.XMLOption{XPath: []string{"//atom:entry/atom:title/text()"}, Namespaces: namespaces},
+			expected: "  Release 1.0 \nRelease 1.1",
+		},
+		{
+			name:     "Namespaced attribute",
+			conf:     domain.XMLOption{XPath: []string{"//m:thumbnail/@url"}, Namespaces: namespaces},
+			expected: "a.png",
+		},
+		{
+			name: "Namespaced element",
+			conf: domain.XMLOption{XPath: []string{"//atom:entry[1]"}, Namespaces: namespaces},
+			expected: `<entry>
+  <title>Release 1.0</title>
+  <media:thumbnail url="a.png" width="10"/>
+</entry>`,
+		},
+		{
+			name:     "Disabled",
+			conf:     domain.XMLOption{},
+			expected: string(input),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewXMLProcessor(domain.Setting{XML: test.conf})
+			actual, err := p.Process(input)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(actual))
+		})
+	}
+}
+
+func TestXMLProcessor_ProcessErrors(t *testing.T) {
+	_, err := processors.NewXMLProcessor(domain.Setting{
+		XML: domain.XMLOption{XPath: []string{"//missing"}},
+	}).Process([]byte(`<feed><entry/></feed>`))
+	assert.ErrorIs(t, err, processors.ErrNoMatches)
+
+	_, err = processors.NewXMLProcessor(domain.Setting{
+		XML: domain.XMLOption{Enabled: true},
+	}).Process([]byte(`<feed><entry></feed>`))
+	assert.ErrorIs(t, err, processors.ErrInvalidContent)
+}
+
+func TestValidateXML(t *testing.T) {
+	assert.NoError(t, processors.ValidateXML(domain.XMLOption{
+		XPath:      []string{"//a:item"},
+		Namespaces: []domain.XMLNamespace{{Prefix: "a", URI: "urn:a"}},
+	}))
+	assert.Error(t, processors.ValidateXML(domain.XMLOption{XPath: []string{"//["}}))
+}
@@ -0,0 +1,100 @@
+package processors
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"strconv"
+	"strings"
+)
+
+type XPathProcessor struct {
+	conf domain.Setting
+}
+
+func NewXPathProcessor(conf domain.Setting) *XPathProcessor {
+	return &XPathProcessor{
+		conf: conf,
+	}
+}
+
+func (p *XPathProcessor) Skip() bool {
+	return len(p.conf.XPath) == 0
+}
+
+// Process evaluates the XPath expressions against the document and writes every result on its own line.
+// Well-formed XML documents are queried as XML, everything else is parsed as HTML.
+func (p *XPathProcessor) Process(body []byte) ([]byte, error) {
+	if p.Skip() {
+		return body, nil
+	}
+
+	navigator, ok := newNavigator(body)
+	if !ok {
+		return nil, ErrInvalidContent
+	}
+
+	var results [][]byte
+	for _, expr := range p.conf.XPath {
+		compiled, err := xpath.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid xpath %q: %w", expr, err)
+		}
+
+		switch value := compiled.Evaluate(navigator.Copy()).(type) {
+		case *xpath.NodeIterator:
+			for value.MoveNext() {
+				results = append(results, []byte(value.Current().Value()))
+			}
+		case float64:
+			results = append(results, []byte(strconv.FormatFloat(value, 'f', -1, 64)))
+		default:
+			results = append(results, []byte(fmt.Sprint(value)))
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatches, strings.Join(p.conf.XPath, ", "))
+	}
+
+	return bytes.Join(results, []byte("\n")), nil
+}
+
+// ValidateXPath checks the XPath expressions compile.
+func ValidateXPath(exprs []string) error {
+	for _, expr := range exprs {
+		if _, err := xpath.Compile(expr); err != nil {
+			return fmt.Errorf("invalid xpath %q: %w", expr, err)
+		}
+	}
+	return nil
+}
+
+func newNavigator(body []byte) (xpath.NodeNavigator, bool) {
+	if isXML(body) {
+		if doc, err := xmlquery.Parse(bytes.NewReader(body)); err == nil {
+			return xmlquery.CreateXPathNavigator(doc), true
+		}
+	}
+
+	doc, err := htmlquery.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, false
+	}
+	return htmlquery.CreateXPathNavigator(doc), true
+}
+
+// isXML reports whether the body looks like an XML document rather than HTML.
+func isXML(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	if bytes.HasPrefix(trimmed, []byte("<?xml")) {
+		return true
+	}
+	lower := bytes.ToLower(trimmed)
+	return bytes.HasPrefix(lower, []byte("<")) &&
+		!bytes.HasPrefix(lower, []byte("<!doctype html")) &&
+		!bytes.Contains(lower, []byte("<html"))
+}
@@ -0,0 +1,108 @@
+package processors_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/processors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXPathProcessor_Process(t *testing.T) {
+	html := []byte(`
+		<html>
+			<body>
+				<h1>Heading 1</h1>
+				<a href="/first">First</a>
+				<a href="/second">Second</a>
+			</body>
+		</html>
+	`)
+	xml := []byte(`<?xml version="1.0"?>
+		<rss>
+			<channel>
+				<item><title>Release 1.0</title><link>https://example.com/1</link></item>
+				<item><title>Release 1.1</title><link>https://example.com/2</link></item>
+			</channel>
+		</rss>
+	`)
+
+	tests := []struct {
+		name     string
+		conf     domain.Setting
+		input    []byte
+		expected []byte
+		wantErr  error
+	}{
+		{
+			name:     "HTML elements",
+			conf:     domain.Setting{XPath: []string{"//h1", "//a"}},
+			input:    html,
+			expected: []byte("Heading 1\nFirst\nSecond"),
+		},
+		{
+			name:     "HTML attributes",
+			conf:     domain.Setting{XPath: []string{"//a/@href"}},
+			input:    html,
+			expected: []byte("/first\n/second"),
+		},
+		{
+			name:     "XML text nodes",
+			conf:     domain.Setting{XPath: []string{"//item/title/text()"}},
+			input:    xml,
+			expected: []byte("Release 1.0\nRelease 1.1"),
+		},
+		{
+			name:     "XML without declaration",
+			conf:     domain.Setting{XPath: []string{"//item[1]/link"}},
+			input:    []byte(`<feed><item><link>https://example.com/1</link></item></feed>`),
+			expected: []byte("https://example.com/1"),
+		},
+		{
+			name:     "Scalar result",
+			conf:     domain.Setting{XPath: []string{"count(//item)"}},
+			input:    xml,
+			expected: []byte("2"),
+		},
+		{
+			name:    "No matches",
+			conf:    domain.Setting{XPath: []string{"//h2", "//a/@title"}},
+			input:   html,
+			wantErr: processors.ErrNoMatches,
+		},
+		{
+			name:     "Without expressions",
+			conf:     domain.Setting{},
+			input:    html,
+			expected: html,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := processors.NewXPathProcessor(test.conf)
+			actual, err := p.Process(test.input)
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, string(test.expected), string(actual))
+		})
+	}
+}
+
+func TestXPathProcessor_Skip(t *testing.T) {
+	assert.True(t, processors.NewXPathProcessor(domain.Setting{}).Skip())
+	assert.False(t, processors.NewXPathProcessor(domain.Setting{XPath: []string{"//h1"}}).Skip())
+}
+
+func TestXPathProcessor_ProcessInvalidExpression(t *testing.T) {
+	p := processors.NewXPathProcessor(domain.Setting{XPath: []string{"//[", "//h1"}})
+
+	_, err := p.Process([]byte("<h1>Heading</h1>"))
+
+	assert.Error(t, err)
+	assert.Error(t, processors.ValidateXPath([]string{"//["}))
+	assert.NoError(t, processors.ValidateXPath([]string{"//h1"}))
+}
@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"errors"
+	"golang.org/x/time/rate"
+	"sync"
+	"time"
+)
+
+var ErrLimitExceeded = errors.New("rate limit exceeded, try again later")
+
+// Limiter allows a number of calls per minute for every key, e.g. a user ID.
+type Limiter struct {
+	mu       sync.Mutex
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+}
+
+// New returns a limiter allowing perMinute calls per key, a non positive value disables the limit.
+func New(perMinute int) *Limiter {
+	return &Limiter{
+		limit:    rate.Every(time.Minute / time.Duration(max(perMinute, 1))),
+		burst:    perMinute,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Allow reports whether a call for the key may happen now.
+func (l *Limiter) Allow(key string) bool {
+	if l == nil || l.burst <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	limiter, ok := l.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[key] = limiter
+	}
+	l.mu.Unlock()
+
+	return limiter.Allow()
+}
@@ -0,0 +1,29 @@
+package ratelimit_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	limiter := ratelimit.New(2)
+
+	assert.True(t, limiter.Allow("user"))
+	assert.True(t, limiter.Allow("user"))
+	assert.False(t, limiter.Allow("user"))
+
+	// Every key has its own budget
+	assert.True(t, limiter.Allow("other"))
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	var nilLimiter *ratelimit.Limiter
+	assert.True(t, nilLimiter.Allow("user"))
+
+	limiter := ratelimit.New(0)
+	for i := 0; i < 10; i++ {
+		assert.True(t, limiter.Allow("user"))
+	}
+}
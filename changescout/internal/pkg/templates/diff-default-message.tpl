@@ -1,3 +1,5 @@
 🌐 {{.Name}} ({{.Mode}})
 🔗 {{.URL}} | ⏱ {{.LastChecked}} {{"\n"}}
-{{- range .Result.Changes }} ({{.Type }}): {{.Content}}{{"\n"}}{{- end }}
\ No newline at end of file
+{{- if .Value }}📈 {{ with .PreviousValue }}{{.}} → {{ end }}{{.Value}}{{"\n"}}{{- end }}
+{{- range .Triggered }}🔔 {{.Type}}: {{.Value}}{{"\n"}}{{- end }}
+{{- range .Result.Changes }} ({{.Type }}){{ with .Path }} {{.}}{{ end }}: {{.Content}}{{"\n"}}{{- end }}
\ No newline at end of file
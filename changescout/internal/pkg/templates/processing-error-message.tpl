@@ -0,0 +1,3 @@
+⚠️ {{.Name}} ({{.Mode}})
+🔗 {{.URL}} | ⏱ {{.LastChecked}} {{"\n"}}
+Processing failed: {{.Error}}
@@ -7,4 +7,7 @@ import (
 var (
 	//go:embed diff-default-message.tpl
 	DiffDefaultMessage string
+
+	//go:embed processing-error-message.tpl
+	ProcessingErrorMessage string
 )
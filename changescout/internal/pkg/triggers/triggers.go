@@ -0,0 +1,79 @@
+package triggers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"regexp"
+)
+
+var (
+	ErrInvalidTriggerType = errors.New("invalid trigger type")
+	ErrEmptyTriggerValue  = errors.New("trigger value is required")
+)
+
+// Validate checks the type and the value of every trigger.
+func Validate(triggers []domain.Trigger) error {
+	for i, trigger := range triggers {
+		if _, err := compile(trigger); err != nil {
+			return fmt.Errorf("trigger %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the triggers which fire for the change from the previous to the current content.
+func Evaluate(triggers []domain.Trigger, previous, current []byte) ([]domain.Trigger, error) {
+	var fired []domain.Trigger
+	for i, trigger := range triggers {
+		re, err := compile(trigger)
+		if err != nil {
+			return nil, fmt.Errorf("trigger %d: %w", i+1, err)
+		}
+
+		if fires(trigger.Type, re.Match(previous), re.Match(current)) {
+			fired = append(fired, trigger)
+		}
+	}
+	return fired, nil
+}
+
+func fires(triggerType domain.TriggerType, before, after bool) bool {
+	switch triggerType {
+	case domain.TriggerContains, domain.TriggerRegex:
+		return after
+	case domain.TriggerNotContains:
+		return !after
+	case domain.TriggerAppears:
+		return !before && after
+	case domain.TriggerDisappears:
+		return before && !after
+	default:
+		return false
+	}
+}
+
+// compile turns the value of the trigger into a pattern, the keywords are matched literally.
+func compile(trigger domain.Trigger) (*regexp.Regexp, error) {
+	if trigger.Value == "" {
+		return nil, ErrEmptyTriggerValue
+	}
+
+	expr := regexp.QuoteMeta(trigger.Value)
+	switch trigger.Type {
+	case domain.TriggerRegex:
+		expr = trigger.Value
+	case domain.TriggerContains, domain.TriggerNotContains, domain.TriggerAppears, domain.TriggerDisappears:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTriggerType, trigger.Type)
+	}
+	if trigger.IgnoreCase {
+		expr = "(?i)" + expr
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trigger regex %q: %w", trigger.Value, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,66 @@
+package triggers_test
+
+import (
+	"testing"
+
+	"github.com/gelleson/changescout/changescout/internal/domain"
+	"github.com/gelleson/changescout/changescout/internal/pkg/triggers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	previous := []byte("Widget Pro: Sold out\nPrice: $19")
+	current := []byte("Widget Pro: In stock\nPrice: $21")
+
+	tests := []struct {
+		name    string
+		trigger domain.Trigger
+		fires   bool
+	}{
+		{name: "Contains", trigger: domain.Trigger{Type: domain.TriggerContains, Value: "Price"}, fires: true},
+		{name: "Contains case sensitive", trigger: domain.Trigger{Type: domain.TriggerContains, Value: "in stock"}, fires: false},
+		{name: "Contains ignore case", trigger: domain.Trigger{Type: domain.TriggerContains, Value: "in stock", IgnoreCase: true}, fires: true},
+		{name: "Not contains", trigger: domain.Trigger{Type: domain.TriggerNotContains, Value: "Sold out"}, fires: true},
+		{name: "Not contains present", trigger: domain.Trigger{Type: domain.TriggerNotContains, Value: "Price"}, fires: false},
+		{name: "Appears", trigger: domain.Trigger{Type: domain.TriggerAppears, Value: "In stock"}, fires: true},
+		{name: "Appears already present", trigger: domain.Trigger{Type: domain.TriggerAppears, Value: "Widget"}, fires: false},
+		{name: "Disappears", trigger: domain.Trigger{Type: domain.TriggerDisappears, Value: "Sold out"}, fires: true},
+		{name: "Disappears never present", trigger: domain.Trigger{Type: domain.TriggerDisappears, Value: "Discontinued"}, fires: false},
+		{name: "Keywords are literal", trigger: domain.Trigger{Type: domain.TriggerContains, Value: "$21"}, fires: true},
+		{name: "Regex", trigger: domain.Trigger{Type: domain.TriggerRegex, Value: `\$2\d`}, fires: true},
+		{name: "Regex no match", trigger: domain.Trigger{Type: domain.TriggerRegex, Value: `\$3\d`}, fires: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := triggers.Evaluate([]domain.Trigger{test.trigger}, previous, current)
+			assert.NoError(t, err)
+			if test.fires {
+				assert.Equal(t, []domain.Trigger{test.trigger}, fired)
+			} else {
+				assert.Empty(t, fired)
+			}
+		})
+	}
+}
+
+func TestEvaluate_FirstCheck(t *testing.T) {
+	fired, err := triggers.Evaluate([]domain.Trigger{
+		{Type: domain.TriggerAppears, Value: "In stock"},
+		{Type: domain.TriggerDisappears, Value: "Sold out"},
+	}, nil, []byte("In stock"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Trigger{{Type: domain.TriggerAppears, Value: "In stock"}}, fired)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, triggers.Validate(nil))
+	assert.NoError(t, triggers.Validate([]domain.Trigger{
+		{Type: domain.TriggerAppears, Value: "("},
+		{Type: domain.TriggerRegex, Value: `\d+`},
+	}))
+	assert.ErrorIs(t, triggers.Validate([]domain.Trigger{{Type: "changes", Value: "a"}}), triggers.ErrInvalidTriggerType)
+	assert.ErrorIs(t, triggers.Validate([]domain.Trigger{{Type: domain.TriggerContains}}), triggers.ErrEmptyTriggerValue)
+	assert.Error(t, triggers.Validate([]domain.Trigger{{Type: domain.TriggerRegex, Value: "("}}))
+}
@@ -20,6 +20,10 @@ export const GET_WEBSITE_BY_ID = gql`query GetWebsiteByID($id: ID!) {
       sort
       selectors
       json_path
+      tls {
+        min_version
+        insecure_skip_verify
+      }
     }
   }
 }`;
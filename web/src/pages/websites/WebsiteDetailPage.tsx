@@ -3,7 +3,7 @@ import { useParams, Link, useNavigate } from 'react-router-dom';
 import { useQuery, useMutation } from '@apollo/client';
 import { GET_WEBSITE_BY_ID, DELETE_WEBSITE, CREATE_PREVIEW_WEBSITE } from '../../lib/graphql/websites';
 import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
-import { Edit, ArrowLeft, Globe, Clock, Settings, Trash2, Menu } from 'lucide-react';
+import { Edit, ArrowLeft, Globe, Clock, Settings, Trash2, Menu, AlertTriangle } from 'lucide-react';
 import { formatDate } from '../../lib/utils';
 import { Sheet} from 'react-modal-sheet';
 
@@ -84,6 +84,16 @@ export function WebsiteDetailPage() {
         </div>
       </div>
 
+      {website.setting.tls?.insecure_skip_verify && (
+        <div className="mb-6 flex items-start rounded-md border border-yellow-300 bg-yellow-50 p-4 text-sm text-yellow-800">
+          <AlertTriangle className="h-5 w-5 mr-2 flex-shrink-0" />
+          <p>
+            TLS certificate verification is disabled for this website. Responses may be intercepted
+            or forged; use this only for trusted internal endpoints.
+          </p>
+        </div>
+      )}
+
       <Sheet isOpen={isPreviewOpen} onClose={() => setPreviewOpen(false)}>
         <Sheet.Container>
           <Sheet.Header>
@@ -167,6 +177,12 @@ export function WebsiteDetailPage() {
               <label className="text-sm font-medium text-gray-500">HTTP Method</label>
               <p className="text-gray-900">{website.setting.method}</p>
             </div>
+            {website.setting.tls?.min_version && (
+              <div>
+                <label className="text-sm font-medium text-gray-500">Minimum TLS Version</label>
+                <p className="text-gray-900">{website.setting.tls.min_version}</p>
+              </div>
+            )}
             <div>
               <label className="text-sm font-medium text-gray-500">User Agent</label>
               <p className="text-gray-900 break-all text-sm">
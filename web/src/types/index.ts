@@ -15,6 +15,10 @@ export interface Website {
     sort: boolean;
     selectors: string[];
     json_path: string[];
+    tls?: {
+      min_version?: string;
+      insecure_skip_verify: boolean;
+    };
   };
 }
 